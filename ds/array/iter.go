@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package array
+
+import "iter"
+
+// All returns an iterator over index-value pairs in the array, from front to back
+func (a *Array[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < len(a.values); i++ {
+			if !yield(i, a.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs in the array, from back to front
+func (a *Array[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(a.values) - 1; i >= 0; i-- {
+			if !yield(i, a.values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the array, from front to back
+func (a *Array[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < len(a.values); i++ {
+			if !yield(a.values[i]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,36 @@
+//go:build go1.23
+
+package array
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestArrayIterSeq(t *testing.T) {
+	a := New[int](5)
+	for i := 0; i < 5; i++ {
+		a.Set(i, i*10)
+	}
+
+	n := 0
+	for i, val := range a.All() {
+		assert.Equal(t, n, i)
+		assert.Equal(t, i*10, val)
+		n++
+	}
+	assert.Equal(t, 5, n)
+
+	for i, val := range a.Backward() {
+		n--
+		assert.Equal(t, n, i)
+		assert.Equal(t, i*10, val)
+	}
+
+	sum := 0
+	for val := range a.Values() {
+		sum += val
+	}
+	assert.Equal(t, 100, sum)
+}
@@ -1,5 +1,14 @@
 package bitmap
 
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+const wordBits = 64
+
 // Bitmap is a mapping from some domain (for example, a range of integers) to bits. It is also called a bit array or bitmap index
 type Bitmap struct {
 	data []byte
@@ -80,3 +89,304 @@ func (b *Bitmap) Clear() {
 func (b *Bitmap) Data() []byte {
 	return b.data
 }
+
+// wordCount returns the amount of 64-bit words the bitmap's data takes
+func (b *Bitmap) wordCount() int {
+	return (len(b.data) + 7) / 8
+}
+
+// word returns the ith 64-bit word of the data in little endian, the bytes beyond the data are regarded as 0
+func (b *Bitmap) word(i int) uint64 {
+	if j := i * 8; j+8 <= len(b.data) {
+		return binary.LittleEndian.Uint64(b.data[j:])
+	}
+	var buf [8]byte
+	copy(buf[:], b.data[i*8:])
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// setWord stores w as the ith 64-bit word of the data, the bits beyond the data are dropped
+func (b *Bitmap) setWord(i int, w uint64) {
+	if j := i * 8; j+8 <= len(b.data) {
+		binary.LittleEndian.PutUint64(b.data[j:], w)
+		return
+	}
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], w)
+	copy(b.data[i*8:], buf[:])
+}
+
+// Clone returns a copy of the bitmap
+func (b *Bitmap) Clone() *Bitmap {
+	data := make([]byte, len(b.data))
+	copy(data, b.data)
+	return &Bitmap{data: data, size: b.size}
+}
+
+// Count returns the amount of 1 in the bitmap
+func (b *Bitmap) Count() uint64 {
+	n := 0
+	for i := 0; i < b.wordCount(); i++ {
+		n += bits.OnesCount64(b.word(i))
+	}
+	return uint64(n)
+}
+
+// And sets the bitmap to the bitwise AND of itself and other, the bits beyond the size of other are regarded as 0.
+// It returns the bitmap itself
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	for i := 0; i < b.wordCount(); i++ {
+		var w uint64
+		if i < other.wordCount() {
+			w = b.word(i) & other.word(i)
+		}
+		b.setWord(i, w)
+	}
+	return b
+}
+
+// Or sets the bitmap to the bitwise OR of itself and other, the bits of other beyond the size of the bitmap are ignored.
+// It returns the bitmap itself
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	for i := 0; i < b.wordCount() && i < other.wordCount(); i++ {
+		b.setWord(i, b.word(i)|other.word(i))
+	}
+	return b
+}
+
+// Xor sets the bitmap to the bitwise XOR of itself and other, the bits of other beyond the size of the bitmap are ignored.
+// It returns the bitmap itself
+func (b *Bitmap) Xor(other *Bitmap) *Bitmap {
+	for i := 0; i < b.wordCount() && i < other.wordCount(); i++ {
+		b.setWord(i, b.word(i)^other.word(i))
+	}
+	return b
+}
+
+// AndNot clears the bits of the bitmap which are set in other. It returns the bitmap itself
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	for i := 0; i < b.wordCount() && i < other.wordCount(); i++ {
+		b.setWord(i, b.word(i)&^other.word(i))
+	}
+	return b
+}
+
+// And returns a new bitmap which is the bitwise AND of a and b, its size is the larger size of a and b
+func And(a, b *Bitmap) *Bitmap {
+	return larger(a, b).And(smaller(a, b))
+}
+
+// Or returns a new bitmap which is the bitwise OR of a and b, its size is the larger size of a and b
+func Or(a, b *Bitmap) *Bitmap {
+	return larger(a, b).Or(smaller(a, b))
+}
+
+// Xor returns a new bitmap which is the bitwise XOR of a and b, its size is the larger size of a and b
+func Xor(a, b *Bitmap) *Bitmap {
+	return larger(a, b).Xor(smaller(a, b))
+}
+
+// AndNot returns a new bitmap with the bits of a which are not set in b, its size is the size of a
+func AndNot(a, b *Bitmap) *Bitmap {
+	return a.Clone().AndNot(b)
+}
+
+// larger returns a copy of the larger one of a and b
+func larger(a, b *Bitmap) *Bitmap {
+	if a.size >= b.size {
+		return a.Clone()
+	}
+	return b.Clone()
+}
+
+func smaller(a, b *Bitmap) *Bitmap {
+	if a.size >= b.size {
+		return b
+	}
+	return a
+}
+
+// NextSet returns the position of the first 1 at or after pos, and false if there is no such position
+func (b *Bitmap) NextSet(pos uint64) (uint64, bool) {
+	if pos >= b.size {
+		return 0, false
+	}
+	i := int(pos / wordBits)
+	w := b.word(i) >> (pos % wordBits)
+	if w != 0 {
+		return pos + uint64(bits.TrailingZeros64(w)), true
+	}
+	for i++; i < b.wordCount(); i++ {
+		if w = b.word(i); w != 0 {
+			return uint64(i)*wordBits + uint64(bits.TrailingZeros64(w)), true
+		}
+	}
+	return 0, false
+}
+
+// NextClear returns the position of the first 0 at or after pos, and false if there is no such position
+func (b *Bitmap) NextClear(pos uint64) (uint64, bool) {
+	if pos >= b.size {
+		return 0, false
+	}
+	i := int(pos / wordBits)
+	w := ^b.word(i) >> (pos % wordBits)
+	if w != 0 {
+		pos += uint64(bits.TrailingZeros64(w))
+		return pos, pos < b.size
+	}
+	for i++; i < b.wordCount(); i++ {
+		if w = ^b.word(i); w != 0 {
+			pos = uint64(i)*wordBits + uint64(bits.TrailingZeros64(w))
+			return pos, pos < b.size
+		}
+	}
+	return 0, false
+}
+
+// PrevSet returns the position of the last 1 at or before pos, and false if there is no such position
+func (b *Bitmap) PrevSet(pos uint64) (uint64, bool) {
+	if b.size == 0 {
+		return 0, false
+	}
+	if pos >= b.size {
+		pos = b.size - 1
+	}
+	i := int(pos / wordBits)
+	w := b.word(i) << (wordBits - 1 - pos%wordBits)
+	if w != 0 {
+		return pos - uint64(bits.LeadingZeros64(w)), true
+	}
+	for i > 0 {
+		i--
+		if w = b.word(i); w != 0 {
+			return uint64(i)*wordBits + wordBits - 1 - uint64(bits.LeadingZeros64(w)), true
+		}
+	}
+	return 0, false
+}
+
+// SetRange sets 1 at the positions in range [lo, hi), the range is truncated by the size
+func (b *Bitmap) SetRange(lo, hi uint64) {
+	b.applyRange(lo, hi, func(w, mask uint64) uint64 { return w | mask })
+}
+
+// ClearRange sets 0 at the positions in range [lo, hi), the range is truncated by the size
+func (b *Bitmap) ClearRange(lo, hi uint64) {
+	b.applyRange(lo, hi, func(w, mask uint64) uint64 { return w &^ mask })
+}
+
+// FlipRange flips the bits at the positions in range [lo, hi), the range is truncated by the size
+func (b *Bitmap) FlipRange(lo, hi uint64) {
+	b.applyRange(lo, hi, func(w, mask uint64) uint64 { return w ^ mask })
+}
+
+// applyRange replaces each word overlapping range [lo, hi) with the result of fn, which is passed the word and the mask of the bits in the range
+func (b *Bitmap) applyRange(lo, hi uint64, fn func(w, mask uint64) uint64) {
+	if hi > b.size {
+		hi = b.size
+	}
+	if lo >= hi {
+		return
+	}
+	first, last := int(lo/wordBits), int((hi-1)/wordBits)
+	loMask := ^uint64(0) << (lo % wordBits)
+	hiMask := ^uint64(0) >> (wordBits - 1 - (hi-1)%wordBits)
+	if first == last {
+		b.setWord(first, fn(b.word(first), loMask&hiMask))
+		return
+	}
+	b.setWord(first, fn(b.word(first), loMask))
+	for i := first + 1; i < last; i++ {
+		b.setWord(i, fn(b.word(i), ^uint64(0)))
+	}
+	b.setWord(last, fn(b.word(last), hiMask))
+}
+
+// Rank returns the amount of 1 at the positions before pos
+func (b *Bitmap) Rank(pos uint64) uint64 {
+	if pos >= b.size {
+		return b.Count()
+	}
+	n := 0
+	last := int(pos / wordBits)
+	for i := 0; i < last; i++ {
+		n += bits.OnesCount64(b.word(i))
+	}
+	n += bits.OnesCount64(b.word(last) & (1<<(pos%wordBits) - 1))
+	return uint64(n)
+}
+
+// Select returns the position of the kth 1 (counting from 0), and false if there are not more than k 1s
+func (b *Bitmap) Select(k uint64) (uint64, bool) {
+	for i := 0; i < b.wordCount(); i++ {
+		w := b.word(i)
+		n := uint64(bits.OnesCount64(w))
+		if k >= n {
+			k -= n
+			continue
+		}
+		// clear the lowest k set bits of the word
+		for ; k > 0; k-- {
+			w &= w - 1
+		}
+		return uint64(i)*wordBits + uint64(bits.TrailingZeros64(w)), true
+	}
+	return 0, false
+}
+
+// Equal returns true if the bitmap has the same size and bits as other
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if b.size != other.size {
+		return false
+	}
+	for i := 0; i < b.wordCount(); i++ {
+		if b.word(i) != other.word(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSubset returns true if all 1s of the bitmap are also 1s of other
+func (b *Bitmap) IsSubset(other *Bitmap) bool {
+	for i := 0; i < b.wordCount(); i++ {
+		var o uint64
+		if i < other.wordCount() {
+			o = other.word(i)
+		}
+		if b.word(i)&^o != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Traversal traversals the positions of 1s in ascending order, it will not stop until to the end or the visitor returns false
+func (b *Bitmap) Traversal(visitor visitor.Visitor[uint64]) {
+	for i := 0; i < b.wordCount(); i++ {
+		for w := b.word(i); w != 0; w &= w - 1 {
+			if !visitor(uint64(i)*wordBits + uint64(bits.TrailingZeros64(w))) {
+				return
+			}
+		}
+	}
+}
+
+// Begin returns an iterator pointing to the first 1
+func (b *Bitmap) Begin() *BitmapIterator {
+	pos, ok := b.NextSet(0)
+	if !ok {
+		pos = b.size
+	}
+	return &BitmapIterator{b: b, pos: pos}
+}
+
+// Last returns an iterator pointing to the last 1
+func (b *Bitmap) Last() *BitmapIterator {
+	pos, ok := b.PrevSet(b.size)
+	if !ok {
+		pos = b.size
+	}
+	return &BitmapIterator{b: b, pos: pos}
+}
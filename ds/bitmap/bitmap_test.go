@@ -1,8 +1,10 @@
 package bitmap
 
 import (
-	"github.com/stretchr/testify/assert"
+	"math/rand"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestBitmap(t *testing.T) {
@@ -65,3 +67,249 @@ func TestResize(t *testing.T) {
 	assert.Equal(t, false, bm.IsSet(20))
 	assert.Equal(t, false, bm.IsSet(77))
 }
+
+// randomBitmap returns a bitmap with random bits and its reference bools
+func randomBitmap(size uint64, density int) (*Bitmap, []bool) {
+	bm := New(size)
+	ref := make([]bool, bm.Size())
+	for i := range ref {
+		if rand.Intn(100) < density {
+			ref[i] = true
+			bm.Set(uint64(i))
+		}
+	}
+	return bm, ref
+}
+
+func checkBitmap(t *testing.T, bm *Bitmap, ref []bool) {
+	assert.Equal(t, uint64(len(ref)), bm.Size())
+	count := uint64(0)
+	for i, v := range ref {
+		assert.Equal(t, v, bm.IsSet(uint64(i)), "pos %v", i)
+		if v {
+			count++
+		}
+	}
+	assert.Equal(t, count, bm.Count())
+}
+
+func TestDataLayout(t *testing.T) {
+	bm := New(20)
+	bm.Set(0)
+	bm.Set(9)
+	bm.Set(23)
+	assert.Equal(t, []byte{0x01, 0x02, 0x80}, bm.Data())
+
+	bm2 := NewFromData([]byte{0x01, 0x02, 0x80, 0, 0, 0, 0, 0, 0xff})
+	assert.Equal(t, uint64(72), bm2.Size())
+	assert.True(t, bm2.IsSet(0))
+	assert.True(t, bm2.IsSet(9))
+	assert.True(t, bm2.IsSet(23))
+	assert.True(t, bm2.IsSet(71))
+	assert.Equal(t, uint64(11), bm2.Count())
+}
+
+func TestDataIsShared(t *testing.T) {
+	// Data returns the internal slice, word operations write through it
+	bm := New(72)
+	data := bm.Data()
+	bm.SetRange(4, 68)
+	assert.Equal(t, []byte{0xf0, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0x0f}, data)
+	data[8] = 0x80
+	assert.True(t, bm.IsSet(71))
+	assert.False(t, bm.IsSet(64))
+	assert.Equal(t, uint64(61), bm.Count())
+
+	// NewFromData wraps data without copying
+	data = []byte{0x01, 0x00}
+	bm = NewFromData(data)
+	data[1] = 0xff
+	assert.True(t, bm.IsSet(8))
+	bm.Or(NewFromData([]byte{0x02}))
+	assert.Equal(t, []byte{0x03, 0xff}, data)
+}
+
+func TestBooleanOps(t *testing.T) {
+	for _, sizes := range [][2]uint64{{200, 200}, {200, 70}, {70, 200}, {8, 130}} {
+		a, ra := randomBitmap(sizes[0], 50)
+		b, rb := randomBitmap(sizes[1], 50)
+		at := func(ref []bool, i int) bool { return i < len(ref) && ref[i] }
+		n := len(ra)
+		if len(rb) > n {
+			n = len(rb)
+		}
+
+		and, or, xor, andNot := make([]bool, n), make([]bool, n), make([]bool, n), make([]bool, len(ra))
+		for i := 0; i < n; i++ {
+			and[i] = at(ra, i) && at(rb, i)
+			or[i] = at(ra, i) || at(rb, i)
+			xor[i] = at(ra, i) != at(rb, i)
+		}
+		for i := range ra {
+			andNot[i] = ra[i] && !at(rb, i)
+		}
+		checkBitmap(t, And(a, b), and)
+		checkBitmap(t, Or(a, b), or)
+		checkBitmap(t, Xor(a, b), xor)
+		checkBitmap(t, AndNot(a, b), andNot)
+
+		// in-place operations keep the size of the receiver
+		checkBitmap(t, a.Clone().And(b), and[:len(ra)])
+		checkBitmap(t, a.Clone().Or(b), or[:len(ra)])
+		checkBitmap(t, a.Clone().Xor(b), xor[:len(ra)])
+		checkBitmap(t, a.Clone().AndNot(b), andNot)
+		checkBitmap(t, a, ra)
+
+		assert.True(t, And(a, b).IsSubset(a))
+		assert.True(t, a.IsSubset(Or(a, b)))
+		assert.True(t, AndNot(a, b).IsSubset(a))
+	}
+}
+
+func TestScan(t *testing.T) {
+	bm, ref := randomBitmap(300, 10)
+	bm.SetRange(100, 200)
+	for i := 100; i < 200; i++ {
+		ref[i] = true
+	}
+	for pos := uint64(0); pos <= 310; pos++ {
+		want, found := uint64(0), false
+		for i := pos; i < uint64(len(ref)); i++ {
+			if ref[i] {
+				want, found = i, true
+				break
+			}
+		}
+		got, ok := bm.NextSet(pos)
+		assert.Equal(t, found, ok)
+		assert.Equal(t, want, got)
+
+		want, found = 0, false
+		for i := pos; i < uint64(len(ref)); i++ {
+			if !ref[i] {
+				want, found = i, true
+				break
+			}
+		}
+		got, ok = bm.NextClear(pos)
+		assert.Equal(t, found, ok)
+		assert.Equal(t, want, got)
+
+		want, found = 0, false
+		for i := int(pos); i >= 0; i-- {
+			if i < len(ref) && ref[i] {
+				want, found = uint64(i), true
+				break
+			}
+		}
+		got, ok = bm.PrevSet(pos)
+		assert.Equal(t, found, ok)
+		assert.Equal(t, want, got)
+	}
+
+	full := New(128)
+	full.SetRange(0, 128)
+	_, ok := full.NextClear(0)
+	assert.False(t, ok)
+	_, ok = New(0).PrevSet(0)
+	assert.False(t, ok)
+}
+
+func TestRange(t *testing.T) {
+	for i := 0; i < 200; i++ {
+		bm, ref := randomBitmap(uint64(rand.Intn(300)), 50)
+		lo, hi := uint64(rand.Intn(320)), uint64(rand.Intn(320))
+		op := rand.Intn(3)
+		switch op {
+		case 0:
+			bm.SetRange(lo, hi)
+		case 1:
+			bm.ClearRange(lo, hi)
+		default:
+			bm.FlipRange(lo, hi)
+		}
+		for j := lo; j < hi && j < uint64(len(ref)); j++ {
+			switch op {
+			case 0:
+				ref[j] = true
+			case 1:
+				ref[j] = false
+			default:
+				ref[j] = !ref[j]
+			}
+		}
+		checkBitmap(t, bm, ref)
+	}
+}
+
+func TestRankSelect(t *testing.T) {
+	bm, ref := randomBitmap(500, 30)
+	rank := uint64(0)
+	for i, v := range ref {
+		assert.Equal(t, rank, bm.Rank(uint64(i)))
+		if v {
+			pos, ok := bm.Select(rank)
+			assert.True(t, ok)
+			assert.Equal(t, uint64(i), pos)
+			rank++
+		}
+	}
+	assert.Equal(t, rank, bm.Rank(1000))
+	_, ok := bm.Select(rank)
+	assert.False(t, ok)
+}
+
+func TestEqualSubset(t *testing.T) {
+	a := New(100)
+	b := New(100)
+	assert.True(t, a.Equal(b))
+	a.Set(3)
+	assert.False(t, a.Equal(b))
+	assert.False(t, a.IsSubset(b))
+	assert.True(t, b.IsSubset(a))
+	b.Set(3)
+	b.Set(50)
+	assert.True(t, a.IsSubset(b))
+	assert.False(t, a.Equal(New(200)))
+
+	c := New(10)
+	c.Set(3)
+	assert.True(t, c.IsSubset(a))
+	assert.False(t, a.IsSubset(c) && a.Equal(c))
+}
+
+func TestBitmapIterator(t *testing.T) {
+	bm, ref := randomBitmap(400, 20)
+	var want []uint64
+	for i, v := range ref {
+		if v {
+			want = append(want, uint64(i))
+		}
+	}
+
+	var got []uint64
+	for iter := bm.Begin(); iter.IsValid(); iter.Next() {
+		got = append(got, iter.Value())
+	}
+	assert.Equal(t, want, got)
+
+	got = got[:0]
+	for iter := bm.Last(); iter.IsValid(); iter.Prev() {
+		got = append([]uint64{iter.Value()}, got...)
+	}
+	assert.Equal(t, want, got)
+
+	got = got[:0]
+	bm.Traversal(func(pos uint64) bool {
+		got = append(got, pos)
+		return len(got) < 3
+	})
+	assert.Equal(t, want[:3], got)
+
+	iter := bm.Begin()
+	clone := iter.Clone()
+	assert.True(t, iter.Equal(clone))
+	clone.Next()
+	assert.False(t, iter.Equal(clone))
+	assert.False(t, New(64).Begin().IsValid())
+}
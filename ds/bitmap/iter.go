@@ -0,0 +1,12 @@
+//go:build go1.23
+
+package bitmap
+
+import "iter"
+
+// All returns an iterator over the positions of 1s in the bitmap, in ascending order
+func (b *Bitmap) All() iter.Seq[uint64] {
+	return func(yield func(uint64) bool) {
+		b.Traversal(yield)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build go1.23
+
+package bitmap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	bm := New(200)
+	bm.Set(3)
+	bm.Set(64)
+	bm.Set(199)
+	var got []uint64
+	for pos := range bm.All() {
+		got = append(got, pos)
+	}
+	assert.Equal(t, []uint64{3, 64, 199}, got)
+}
@@ -0,0 +1,66 @@
+package bitmap
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+var _ iterator.ConstBidIterator[uint64] = (*BitmapIterator)(nil)
+
+// BitmapIterator is an iterator over the positions of 1s in a bitmap
+type BitmapIterator struct {
+	b   *Bitmap
+	pos uint64 // b.size means invalid
+}
+
+// IsValid returns true if the iterator is valid, otherwise returns false
+func (iter *BitmapIterator) IsValid() bool {
+	return iter.pos < iter.b.size && iter.b.IsSet(iter.pos)
+}
+
+// Next moves the iterator to the next 1, and returns itself
+func (iter *BitmapIterator) Next() iterator.ConstIterator[uint64] {
+	if iter.pos >= iter.b.size {
+		return iter
+	}
+	pos, ok := iter.b.NextSet(iter.pos + 1)
+	if !ok {
+		pos = iter.b.size
+	}
+	iter.pos = pos
+	return iter
+}
+
+// Prev moves the iterator to the previous 1, and returns itself
+func (iter *BitmapIterator) Prev() iterator.ConstBidIterator[uint64] {
+	if iter.pos >= iter.b.size {
+		return iter
+	}
+	pos, ok := uint64(0), false
+	if iter.pos > 0 {
+		pos, ok = iter.b.PrevSet(iter.pos - 1)
+	}
+	if !ok {
+		pos = iter.b.size
+	}
+	iter.pos = pos
+	return iter
+}
+
+// Value returns the position of the 1 the iterator point to
+func (iter *BitmapIterator) Value() uint64 {
+	return iter.pos
+}
+
+// Clone clones the iterator to a new BitmapIterator
+func (iter *BitmapIterator) Clone() iterator.ConstIterator[uint64] {
+	return &BitmapIterator{b: iter.b, pos: iter.pos}
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *BitmapIterator) Equal(other iterator.ConstIterator[uint64]) bool {
+	otherIter, ok := other.(*BitmapIterator)
+	if !ok {
+		return false
+	}
+	return otherIter.b == iter.b && otherIter.pos == iter.pos
+}
@@ -16,7 +16,10 @@ var defaultLocker sync.FakeLocker
 
 // Options holds BloomFilter's options
 type Options struct {
-	locker sync.Locker
+	locker          sync.Locker
+	counterWidth    uint8
+	tighteningRatio float64
+	growthFactor    float64
 }
 
 // Option is a function type used to set Options
@@ -37,14 +40,22 @@ type BloomFilter struct {
 	locker sync.Locker
 }
 
-// New creates a new BloomFilter with m bits and k hash functions
-func New(m, k uint64, opts ...Option) *BloomFilter {
+func newOptions(opts []Option) Options {
 	opt := Options{
-		locker: defaultLocker,
+		locker:          defaultLocker,
+		counterWidth:    DefaultCounterWidth,
+		tighteningRatio: DefaultTighteningRatio,
+		growthFactor:    DefaultGrowthFactor,
 	}
 	for _, o := range opts {
 		o(&opt)
 	}
+	return opt
+}
+
+// New creates a new BloomFilter with m bits and k hash functions
+func New(m, k uint64, opts ...Option) *BloomFilter {
+	opt := newOptions(opts)
 	return &BloomFilter{
 		m:      m,
 		k:      k,
@@ -63,12 +74,7 @@ func NewWithEstimates(n uint64, fp float64, opts ...Option) *BloomFilter {
 
 // NewFromData creates a new BloomFilter from data generated by function 'Data()'
 func NewFromData(data []byte, opts ...Option) *BloomFilter {
-	opt := Options{
-		locker: defaultLocker,
-	}
-	for _, o := range opts {
-		o(&opt)
-	}
+	opt := newOptions(opts)
 	b := &BloomFilter{
 		locker: opt.locker,
 	}
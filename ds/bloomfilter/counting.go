@@ -0,0 +1,206 @@
+package bloom
+
+import (
+	"encoding/binary"
+
+	"github.com/liyue201/gostl/algorithm/hash"
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/liyue201/gostl/utils/sync"
+)
+
+// DefaultCounterWidth is the default width in bits of the counters of a CountingBloomFilter
+const DefaultCounterWidth = 4
+
+// WithCounterWidth sets the width in bits of the counters of a CountingBloomFilter, it must be 2, 4, 8 or 16.
+// The default is DefaultCounterWidth
+func WithCounterWidth(width uint8) Option {
+	return func(opt *Options) {
+		switch width {
+		case 2, 4, 8, 16:
+			opt.counterWidth = width
+		}
+	}
+}
+
+// CountingBloomFilter is a bloom filter with a counter instead of a bit at each position, so values can be removed.
+// A counter reaching its max value is saturated and never decremented, which may cause false positives after
+// removals but never false negatives
+type CountingBloomFilter struct {
+	m         uint64
+	k         uint64
+	width     uint8
+	counters  []uint64 // counters packed in words
+	overflows uint64
+	locker    sync.Locker
+}
+
+// NewCounting creates a new CountingBloomFilter with m counters and k hash functions
+func NewCounting(m, k uint64, opts ...Option) *CountingBloomFilter {
+	opt := newOptions(opts)
+	bf := &CountingBloomFilter{
+		m:      m,
+		k:      k,
+		width:  opt.counterWidth,
+		locker: opt.locker,
+	}
+	bf.counters = make([]uint64, (m+bf.perWord()-1)/bf.perWord())
+	return bf
+}
+
+// NewCountingWithEstimates creates a new CountingBloomFilter with n and fp.
+// n is the capacity of the CountingBloomFilter
+// fp is the tolerated error rate of the CountingBloomFilter
+func NewCountingWithEstimates(n uint64, fp float64, opts ...Option) *CountingBloomFilter {
+	m, k := EstimateParameters(n, fp)
+	return NewCounting(m, k, opts...)
+}
+
+// NewCountingFromData creates a new CountingBloomFilter from data generated by function 'Data()',
+// the counter width is read from data
+func NewCountingFromData(data []byte, opts ...Option) (*CountingBloomFilter, error) {
+	if len(data) < 8+8+1+8 {
+		return nil, codec.ErrorCorruptData
+	}
+	bf := NewCounting(0, 0, opts...)
+	bf.m = binary.LittleEndian.Uint64(data)
+	bf.k = binary.LittleEndian.Uint64(data[8:])
+	bf.width = data[16]
+	bf.overflows = binary.LittleEndian.Uint64(data[17:])
+	data = data[25:]
+	if bf.m == 0 || bf.k == 0 {
+		return nil, codec.ErrorCorruptData
+	}
+	switch bf.width {
+	case 2, 4, 8, 16:
+	default:
+		return nil, codec.ErrorCorruptData
+	}
+	words := (bf.m + bf.perWord() - 1) / bf.perWord()
+	if uint64(len(data)) != words*8 {
+		return nil, codec.ErrorCorruptData
+	}
+	bf.counters = make([]uint64, words)
+	for i := range bf.counters {
+		bf.counters[i] = binary.LittleEndian.Uint64(data[i*8:])
+	}
+	return bf, nil
+}
+
+func (bf *CountingBloomFilter) perWord() uint64 {
+	return 64 / uint64(bf.width)
+}
+
+func (bf *CountingBloomFilter) maxCount() uint64 {
+	return 1<<bf.width - 1
+}
+
+func (bf *CountingBloomFilter) get(pos uint64) uint64 {
+	shift := pos % bf.perWord() * uint64(bf.width)
+	return bf.counters[pos/bf.perWord()] >> shift & bf.maxCount()
+}
+
+func (bf *CountingBloomFilter) set(pos, value uint64) {
+	shift := pos % bf.perWord() * uint64(bf.width)
+	w := &bf.counters[pos/bf.perWord()]
+	*w = *w&^(bf.maxCount()<<shift) | (value&bf.maxCount())<<shift
+}
+
+// positions returns the distinct counter positions of val, a position is never counted twice for one value
+// even if the hash values repeat (GenHashInts repeats them when k > 8)
+func (bf *CountingBloomFilter) positions(val string) []uint64 {
+	hashs := hash.GenHashInts([]byte(salt+val), int(bf.k))
+	n := 0
+next:
+	for _, h := range hashs {
+		pos := h % bf.m
+		for _, p := range hashs[:n] {
+			if p == pos {
+				continue next
+			}
+		}
+		hashs[n] = pos
+		n++
+	}
+	return hashs[:n]
+}
+
+// Add adds val to the CountingBloomFilter
+func (bf *CountingBloomFilter) Add(val string) {
+	bf.locker.Lock()
+	defer bf.locker.Unlock()
+
+	for _, pos := range bf.positions(val) {
+		c := bf.get(pos)
+		if c == bf.maxCount() {
+			bf.overflows++
+			continue
+		}
+		bf.set(pos, c+1)
+	}
+}
+
+// Remove removes val from the CountingBloomFilter, it returns false and does nothing if val is not in the filter.
+// Only remove values which have been added, otherwise other values may be removed as well
+func (bf *CountingBloomFilter) Remove(val string) bool {
+	bf.locker.Lock()
+	defer bf.locker.Unlock()
+
+	positions := bf.positions(val)
+	for _, pos := range positions {
+		if bf.get(pos) == 0 {
+			return false
+		}
+	}
+	for _, pos := range positions {
+		// a saturated counter has lost its real value, so it is never decremented
+		if c := bf.get(pos); c != bf.maxCount() {
+			bf.set(pos, c-1)
+		}
+	}
+	return true
+}
+
+// Contains returns true if val is (high probability) in the CountingBloomFilter, otherwise returns false.
+func (bf *CountingBloomFilter) Contains(val string) bool {
+	return bf.EstimateCount(val) > 0
+}
+
+// EstimateCount returns the estimated amount of times val has been added, it is never less than the real amount
+// unless the counters of val are saturated
+func (bf *CountingBloomFilter) EstimateCount(val string) uint64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	count := bf.maxCount()
+	for _, pos := range bf.positions(val) {
+		if c := bf.get(pos); c < count {
+			count = c
+		}
+	}
+	return count
+}
+
+// Overflows returns the amount of increments dropped because of saturated counters
+func (bf *CountingBloomFilter) Overflows() uint64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	return bf.overflows
+}
+
+// Data returns the data of the CountingBloomFilter, it can bee used to creates a new CountingBloomFilter by using
+// function 'NewCountingFromData'
+func (bf *CountingBloomFilter) Data() []byte {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	data := make([]byte, 25+len(bf.counters)*8)
+	binary.LittleEndian.PutUint64(data, bf.m)
+	binary.LittleEndian.PutUint64(data[8:], bf.k)
+	data[16] = bf.width
+	binary.LittleEndian.PutUint64(data[17:], bf.overflows)
+	for i, w := range bf.counters {
+		binary.LittleEndian.PutUint64(data[25+i*8:], w)
+	}
+	return data
+}
@@ -0,0 +1,134 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"strconv"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCountingBloomFilter(t *testing.T) {
+	b := NewCountingWithEstimates(1000, 0.001, WithGoroutineSafe())
+	assert.False(t, b.Contains("aa"))
+	assert.False(t, b.Remove("aa"))
+
+	b.Add("aa")
+	b.Add("aa")
+	b.Add("bb")
+	assert.True(t, b.Contains("aa"))
+	assert.Equal(t, uint64(2), b.EstimateCount("aa"))
+	assert.Equal(t, uint64(1), b.EstimateCount("bb"))
+
+	assert.True(t, b.Remove("aa"))
+	assert.True(t, b.Contains("aa"))
+	assert.True(t, b.Remove("aa"))
+	assert.False(t, b.Contains("aa"))
+	assert.True(t, b.Contains("bb"))
+	assert.Equal(t, uint64(0), b.Overflows())
+}
+
+func TestCountingBloomFilterMany(t *testing.T) {
+	b := NewCountingWithEstimates(1000, 0.01)
+	for i := 0; i < 1000; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	for i := 0; i < 1000; i++ {
+		assert.True(t, b.Contains(strconv.Itoa(i)))
+	}
+	for i := 0; i < 1000; i += 2 {
+		assert.True(t, b.Remove(strconv.Itoa(i)))
+	}
+	falsePositives := 0
+	for i := 0; i < 1000; i++ {
+		if i%2 == 1 {
+			assert.True(t, b.Contains(strconv.Itoa(i)))
+		} else if b.Contains(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, falsePositives, 30)
+}
+
+func TestCountingBloomFilterManyHashes(t *testing.T) {
+	// GenHashInts repeats its hash values when k > 8, each position must be counted once
+	b := NewCounting(1000, 16)
+	b.Add("a")
+	assert.Equal(t, uint64(1), b.EstimateCount("a"))
+	assert.True(t, b.Remove("a"))
+	assert.False(t, b.Contains("a"))
+
+	// removing a value which was never added must not wrap a counter
+	b = NewCounting(16, 16)
+	b.Add("x")
+	b.Remove("336")
+	for pos := uint64(0); pos < b.m; pos++ {
+		assert.LessOrEqual(t, b.get(pos), uint64(1))
+	}
+	assert.Equal(t, uint64(0), b.Overflows())
+}
+
+func TestCountingBloomFilterOverflow(t *testing.T) {
+	for _, width := range []uint8{2, 4, 8, 16} {
+		b := NewCounting(1000, 3, WithCounterWidth(width))
+		maxCount := uint64(1)<<width - 1
+		n := int(maxCount) + 2
+		if width == 16 {
+			n = 10
+		}
+		for i := 0; i < n; i++ {
+			b.Add("x")
+		}
+		b.Add("y")
+		if width == 16 {
+			assert.Equal(t, uint64(10), b.EstimateCount("x"))
+			continue
+		}
+		assert.Equal(t, maxCount, b.EstimateCount("x"))
+		assert.Equal(t, uint64(6), b.Overflows())
+
+		// saturated counters are never decremented, so x is never lost
+		for i := 0; i < n+5; i++ {
+			b.Remove("x")
+		}
+		assert.True(t, b.Contains("x"))
+		assert.Equal(t, uint64(1), b.EstimateCount("y"))
+	}
+
+	// unsupported widths are ignored
+	assert.Equal(t, uint8(DefaultCounterWidth), NewCounting(10, 1, WithCounterWidth(3)).width)
+}
+
+func TestCountingBloomFilterData(t *testing.T) {
+	b := NewCounting(1000, 5, WithCounterWidth(8))
+	b.Add("aa")
+	b.Add("aa")
+	b.Add("bb")
+
+	other, err := NewCountingFromData(b.Data(), WithGoroutineSafe())
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(2), other.EstimateCount("aa"))
+	assert.Equal(t, uint64(1), other.EstimateCount("bb"))
+	assert.False(t, other.Contains("cc"))
+	assert.Equal(t, b.Data(), other.Data())
+
+	data := b.Data()
+	_, err = NewCountingFromData(data[:len(data)-1])
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	_, err = NewCountingFromData(data[:10])
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	// m is 0 with an empty payload
+	bad := append([]byte{}, data[:25]...)
+	binary.LittleEndian.PutUint64(bad, 0)
+	_, err = NewCountingFromData(bad)
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	// k is 0
+	bad = append([]byte{}, data...)
+	binary.LittleEndian.PutUint64(bad[8:], 0)
+	_, err = NewCountingFromData(bad)
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	data[16] = 5
+	_, err = NewCountingFromData(data)
+	assert.Equal(t, codec.ErrorCorruptData, err)
+}
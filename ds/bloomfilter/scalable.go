@@ -0,0 +1,266 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/liyue201/gostl/utils/sync"
+)
+
+// Default parameters of ScalableBloomFilter
+const (
+	DefaultFalsePositiveRate = 0.01
+	DefaultTighteningRatio   = 0.9
+	DefaultGrowthFactor      = 2
+)
+
+// WithTighteningRatio sets the ratio by which the error rate of each new sub-filter of a ScalableBloomFilter is
+// tightened, it must be in range (0, 1). The default is DefaultTighteningRatio
+func WithTighteningRatio(r float64) Option {
+	return func(opt *Options) {
+		if r > 0 && r < 1 {
+			opt.tighteningRatio = r
+		}
+	}
+}
+
+// WithGrowthFactor sets the factor by which the capacity of each new sub-filter of a ScalableBloomFilter grows,
+// it must be at least 1. The default is DefaultGrowthFactor
+func WithGrowthFactor(s float64) Option {
+	return func(opt *Options) {
+		if s >= 1 {
+			opt.growthFactor = s
+		}
+	}
+}
+
+// FillRatio returns the ratio of set bits in the BloomFilter
+func (bf *BloomFilter) FillRatio() float64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	return bf.fillRatio()
+}
+
+// FalsePositiveRate returns the estimated false positive rate of the BloomFilter by its fill ratio
+func (bf *BloomFilter) FalsePositiveRate() float64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	return bf.falsePositiveRate()
+}
+
+func (bf *BloomFilter) fillRatio() float64 {
+	if bf.m == 0 {
+		return 0
+	}
+	return float64(bf.b.Count()) / float64(bf.m)
+}
+
+func (bf *BloomFilter) falsePositiveRate() float64 {
+	return math.Pow(bf.fillRatio(), float64(bf.k))
+}
+
+// subFilter is a BloomFilter in the chain of a ScalableBloomFilter
+type subFilter struct {
+	*BloomFilter
+	capacity uint64
+	count    uint64
+}
+
+// ScalableBloomFilter is a bloom filter which grows by chaining sub-filters with geometrically increasing capacities
+// and tightening error rates, as described by Almeida et al, "Scalable Bloom Filters".
+// The false positive rate is kept under the passed bound however many values are added
+type ScalableBloomFilter struct {
+	n       uint64  // capacity of the first sub-filter
+	fp      float64 // the false positive bound
+	r       float64 // tightening ratio
+	s       float64 // growth factor
+	filters []*subFilter
+	locker  sync.Locker
+}
+
+// NewScalable creates a new ScalableBloomFilter whose first sub-filter holds n values,
+// fp is the tolerated error rate of the whole ScalableBloomFilter, it must be in range (0, 1), otherwise DefaultFalsePositiveRate is used
+func NewScalable(n uint64, fp float64, opts ...Option) *ScalableBloomFilter {
+	opt := newOptions(opts)
+	if n == 0 {
+		n = 1
+	}
+	if !(fp > 0 && fp < 1) {
+		fp = DefaultFalsePositiveRate
+	}
+	bf := &ScalableBloomFilter{
+		n:      n,
+		fp:     fp,
+		r:      opt.tighteningRatio,
+		s:      opt.growthFactor,
+		locker: opt.locker,
+	}
+	bf.grow()
+	return bf
+}
+
+// grow appends a new sub-filter with the next capacity and error rate
+func (bf *ScalableBloomFilter) grow() {
+	i := float64(len(bf.filters))
+	capacity := uint64(math.Ceil(float64(bf.n) * math.Pow(bf.s, i)))
+	// the error rates of the sub-filters form a geometric series summing up to fp
+	fp := bf.fp * (1 - bf.r) * math.Pow(bf.r, i)
+	m, k := EstimateParameters(capacity, fp)
+	bf.filters = append(bf.filters, &subFilter{BloomFilter: New(m, k), capacity: capacity})
+}
+
+// Add adds val to the ScalableBloomFilter, a new sub-filter is added if the current one is full
+func (bf *ScalableBloomFilter) Add(val string) {
+	bf.locker.Lock()
+	defer bf.locker.Unlock()
+
+	if bf.contains(val) {
+		return
+	}
+	last := bf.filters[len(bf.filters)-1]
+	if last.count >= last.capacity {
+		bf.grow()
+		last = bf.filters[len(bf.filters)-1]
+	}
+	last.Add(val)
+	last.count++
+}
+
+// Contains returns true if val is (high probability) in the ScalableBloomFilter, otherwise returns false.
+func (bf *ScalableBloomFilter) Contains(val string) bool {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	return bf.contains(val)
+}
+
+func (bf *ScalableBloomFilter) contains(val string) bool {
+	// the latest sub-filter holds the most values
+	for i := len(bf.filters) - 1; i >= 0; i-- {
+		if bf.filters[i].Contains(val) {
+			return true
+		}
+	}
+	return false
+}
+
+// Count returns the amount of values added, the values regarded as already added are not counted
+func (bf *ScalableBloomFilter) Count() uint64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	count := uint64(0)
+	for _, f := range bf.filters {
+		count += f.count
+	}
+	return count
+}
+
+// Filters returns the amount of sub-filters
+func (bf *ScalableBloomFilter) Filters() int {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	return len(bf.filters)
+}
+
+// FillRatio returns the ratio of set bits in all sub-filters
+func (bf *ScalableBloomFilter) FillRatio() float64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	var set, total uint64
+	for _, f := range bf.filters {
+		set += f.b.Count()
+		total += f.m
+	}
+	return float64(set) / float64(total)
+}
+
+// FalsePositiveRate returns the estimated false positive rate by the fill ratios of the sub-filters,
+// it is the probability that at least one sub-filter reports a false positive
+func (bf *ScalableBloomFilter) FalsePositiveRate() float64 {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	negative := 1.0
+	for _, f := range bf.filters {
+		negative *= 1 - f.falsePositiveRate()
+	}
+	return 1 - negative
+}
+
+// Data returns the data of the ScalableBloomFilter including all sub-filters, it can bee used to creates a new
+// ScalableBloomFilter by using function 'NewScalableFromData'
+func (bf *ScalableBloomFilter) Data() []byte {
+	bf.locker.RLock()
+	defer bf.locker.RUnlock()
+
+	data := make([]byte, 40, 40+len(bf.filters)*24)
+	binary.LittleEndian.PutUint64(data, bf.n)
+	binary.LittleEndian.PutUint64(data[8:], math.Float64bits(bf.fp))
+	binary.LittleEndian.PutUint64(data[16:], math.Float64bits(bf.r))
+	binary.LittleEndian.PutUint64(data[24:], math.Float64bits(bf.s))
+	binary.LittleEndian.PutUint64(data[32:], uint64(len(bf.filters)))
+	var buf [8]byte
+	for _, f := range bf.filters {
+		fd := f.Data()
+		for _, v := range []uint64{f.capacity, f.count, uint64(len(fd))} {
+			binary.LittleEndian.PutUint64(buf[:], v)
+			data = append(data, buf[:]...)
+		}
+		data = append(data, fd...)
+	}
+	return data
+}
+
+// NewScalableFromData creates a new ScalableBloomFilter from data generated by function 'Data()'
+func NewScalableFromData(data []byte, opts ...Option) (*ScalableBloomFilter, error) {
+	if len(data) < 40 {
+		return nil, codec.ErrorCorruptData
+	}
+	opt := newOptions(opts)
+	bf := &ScalableBloomFilter{
+		n:      binary.LittleEndian.Uint64(data),
+		fp:     math.Float64frombits(binary.LittleEndian.Uint64(data[8:])),
+		r:      math.Float64frombits(binary.LittleEndian.Uint64(data[16:])),
+		s:      math.Float64frombits(binary.LittleEndian.Uint64(data[24:])),
+		locker: opt.locker,
+	}
+	// the parameters are checked the same way as NewScalable and the options
+	if bf.n == 0 || !(bf.fp > 0 && bf.fp < 1) || !(bf.r > 0 && bf.r < 1) || !(bf.s >= 1) {
+		return nil, codec.ErrorCorruptData
+	}
+	n := binary.LittleEndian.Uint64(data[32:])
+	data = data[40:]
+	if n == 0 || n > uint64(len(data))/24 {
+		return nil, codec.ErrorCorruptData
+	}
+	for i := uint64(0); i < n; i++ {
+		if len(data) < 24 {
+			return nil, codec.ErrorCorruptData
+		}
+		f := &subFilter{
+			capacity: binary.LittleEndian.Uint64(data),
+			count:    binary.LittleEndian.Uint64(data[8:]),
+		}
+		size := binary.LittleEndian.Uint64(data[16:])
+		data = data[24:]
+		if size < 16 || size > uint64(len(data)) {
+			return nil, codec.ErrorCorruptData
+		}
+		f.BloomFilter = NewFromData(data[:size])
+		if f.m == 0 || f.k == 0 || f.b.Size() < f.m {
+			return nil, codec.ErrorCorruptData
+		}
+		data = data[size:]
+		bf.filters = append(bf.filters, f)
+	}
+	if len(data) != 0 {
+		return nil, codec.ErrorCorruptData
+	}
+	return bf, nil
+}
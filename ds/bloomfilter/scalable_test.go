@@ -0,0 +1,131 @@
+package bloom
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScalableBloomFilter(t *testing.T) {
+	b := NewScalable(100, 0.01, WithGoroutineSafe())
+	assert.Equal(t, 1, b.Filters())
+	assert.Equal(t, 0.0, b.FillRatio())
+	assert.Equal(t, 0.0, b.FalsePositiveRate())
+
+	for i := 0; i < 2000; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	// capacities are 100, 200, 400, 800, 1600
+	assert.Equal(t, 5, b.Filters())
+	assert.LessOrEqual(t, b.Count(), uint64(2000))
+	assert.Greater(t, b.Count(), uint64(1950))
+	for i := 0; i < 2000; i++ {
+		assert.True(t, b.Contains(strconv.Itoa(i)))
+	}
+
+	falsePositives := 0
+	for i := 2000; i < 22000; i++ {
+		if b.Contains(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	assert.Less(t, float64(falsePositives)/20000, 0.01)
+	assert.Less(t, b.FalsePositiveRate(), 0.01)
+	assert.Greater(t, b.FalsePositiveRate(), 0.0)
+	assert.Greater(t, b.FillRatio(), 0.0)
+	assert.Less(t, b.FillRatio(), 0.5)
+
+	// values already in the filter are not counted again
+	count := b.Count()
+	b.Add("1")
+	assert.Equal(t, count, b.Count())
+}
+
+func TestScalableBloomFilterOptions(t *testing.T) {
+	b := NewScalable(10, 0.001, WithGrowthFactor(4), WithTighteningRatio(0.5))
+	for i := 0; i < 50; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	// capacities are 10, 40
+	assert.Equal(t, 2, b.Filters())
+	assert.Equal(t, uint64(40), b.filters[1].capacity)
+	m, k := EstimateParameters(40, 0.001*0.5*0.5)
+	assert.Equal(t, m, b.filters[1].m)
+	assert.Equal(t, k, b.filters[1].k)
+
+	// invalid options are ignored
+	b = NewScalable(10, 0.001, WithGrowthFactor(0.5), WithTighteningRatio(1))
+	assert.Equal(t, float64(DefaultGrowthFactor), b.s)
+	assert.Equal(t, DefaultTighteningRatio, b.r)
+
+	// fp out of range (0, 1) falls back to the default
+	for _, fp := range []float64{0, 1, -0.5, math.NaN()} {
+		b = NewScalable(10, fp)
+		assert.Equal(t, DefaultFalsePositiveRate, b.fp)
+	}
+}
+
+func TestScalableBloomFilterData(t *testing.T) {
+	b := NewScalable(50, 0.01)
+	for i := 0; i < 300; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	data := b.Data()
+	other, err := NewScalableFromData(data, WithGoroutineSafe())
+	assert.Nil(t, err)
+	assert.Equal(t, b.Filters(), other.Filters())
+	assert.Equal(t, b.Count(), other.Count())
+	assert.Equal(t, b.FalsePositiveRate(), other.FalsePositiveRate())
+	for i := 0; i < 300; i++ {
+		assert.True(t, other.Contains(strconv.Itoa(i)))
+	}
+	assert.Equal(t, data, other.Data())
+
+	// the restored filter keeps growing
+	for i := 300; i < 1000; i++ {
+		other.Add(strconv.Itoa(i))
+	}
+	assert.Greater(t, other.Filters(), b.Filters())
+
+	for _, bad := range [][]byte{data[:39], data[:len(data)-1], append(data, 0)} {
+		_, err = NewScalableFromData(bad)
+		assert.Equal(t, codec.ErrorCorruptData, err)
+	}
+	// n is 0, fp or r is out of range (0, 1), or s is less than 1
+	nan := math.Float64bits(math.NaN())
+	for _, field := range []struct {
+		offset int
+		value  uint64
+	}{{0, 0}, {8, 0}, {8, math.Float64bits(1)}, {8, nan}, {16, 0}, {16, math.Float64bits(1)}, {16, nan},
+		{24, math.Float64bits(0.5)}, {24, nan}} {
+		bad := append([]byte{}, data...)
+		binary.LittleEndian.PutUint64(bad[field.offset:], field.value)
+		_, err = NewScalableFromData(bad)
+		assert.Equal(t, codec.ErrorCorruptData, err)
+	}
+	// m, k of the first sub-filter are 0 or m is larger than its bitmap
+	for _, field := range []struct {
+		offset int
+		value  uint64
+	}{{64, 0}, {72, 0}, {64, 1 << 40}} {
+		bad := append([]byte{}, data...)
+		binary.LittleEndian.PutUint64(bad[field.offset:], field.value)
+		_, err = NewScalableFromData(bad)
+		assert.Equal(t, codec.ErrorCorruptData, err)
+	}
+}
+
+func TestBloomFilterFillRatio(t *testing.T) {
+	b := NewWithEstimates(1000, 0.01)
+	assert.Equal(t, 0.0, b.FillRatio())
+	for i := 0; i < 1000; i++ {
+		b.Add(strconv.Itoa(i))
+	}
+	// a filter filled to its capacity has about half of the bits set
+	assert.InDelta(t, 0.5, b.FillRatio(), 0.05)
+	assert.InDelta(t, 0.01, b.FalsePositiveRate(), 0.005)
+}
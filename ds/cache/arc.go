@@ -0,0 +1,237 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/liyue201/gostl/ds/list/bidlist"
+)
+
+var _ Cache[int, int] = (*ARC[int, int])(nil)
+
+// arcEntry is an entry of ARC which knows the list it belongs to
+type arcEntry[K comparable, V any] struct {
+	entry[K, V]
+	frequent bool // true if the entry is in t2
+}
+
+// ghost is a key evicted from ARC, it is remembered to adapt the target size of t1
+type ghost[K comparable] struct {
+	node     *bidlist.Node[K]
+	frequent bool // true if the key is in b2
+}
+
+// ARC is an adaptive replacement cache, it keeps both recently used entries (t1) and frequently used entries (t2),
+// and adapts the target size of t1 by tracking the keys recently evicted from t1 (b1) and t2 (b2).
+// All lists are ordered from the most recently used to the least recently used
+type ARC[K comparable, V any] struct {
+	base[K, V]
+	p      int // target size of t1
+	t1     *bidlist.List[*arcEntry[K, V]]
+	t2     *bidlist.List[*arcEntry[K, V]]
+	b1     *bidlist.List[K]
+	b2     *bidlist.List[K]
+	items  map[K]*bidlist.Node[*arcEntry[K, V]]
+	ghosts map[K]ghost[K]
+}
+
+// NewARC creates an ARC cache holding at most capacity entries, it remembers at most capacity evicted keys as well
+func NewARC[K comparable, V any](capacity int, opts ...Option[K, V]) *ARC[K, V] {
+	return &ARC[K, V]{
+		base:   newBase(capacity, opts),
+		t1:     bidlist.New[*arcEntry[K, V]](),
+		t2:     bidlist.New[*arcEntry[K, V]](),
+		b1:     bidlist.New[K](),
+		b2:     bidlist.New[K](),
+		items:  make(map[K]*bidlist.Node[*arcEntry[K, V]]),
+		ghosts: make(map[K]ghost[K]),
+	}
+}
+
+// Get returns the value of the key and moves the key to the frequently used list
+func (c *ARC[K, V]) Get(key K) (V, bool) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	node, ok := c.items[key]
+	if !ok || c.expire(node) {
+		c.stats.Misses++
+		return *new(V), false
+	}
+	c.stats.Hits++
+	c.promote(node)
+	return node.Value.value, true
+}
+
+// Peek returns the value of the key without moving the key
+func (c *ARC[K, V]) Peek(key K) (V, bool) {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	node, ok := c.items[key]
+	if !ok || c.expired(&node.Value.entry) {
+		return *new(V), false
+	}
+	return node.Value.value, true
+}
+
+// Put puts a key-value pair with the default TTL into the cache
+func (c *ARC[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL puts a key-value pair which expires after ttl into the cache, an entry is evicted if the cache is full
+func (c *ARC[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	e := &arcEntry[K, V]{entry: entry[K, V]{key: key, value: value, expireAt: c.expireAt(ttl)}}
+	if node, ok := c.items[key]; ok {
+		node.Value.value = value
+		node.Value.expireAt = e.expireAt
+		c.promote(node)
+		return
+	}
+
+	if g, ok := c.ghosts[key]; ok {
+		// the key was evicted recently, adapt the target size of t1 and put it into t2
+		if g.frequent {
+			c.p -= ratio(c.b1.Len(), c.b2.Len())
+			if c.p < 0 {
+				c.p = 0
+			}
+			c.b2.Remove(g.node)
+		} else {
+			c.p += ratio(c.b2.Len(), c.b1.Len())
+			if c.p > c.capacity {
+				c.p = c.capacity
+			}
+			c.b1.Remove(g.node)
+		}
+		delete(c.ghosts, key)
+		if c.full() {
+			c.replace(g.frequent)
+		}
+		e.frequent = true
+		c.items[key] = c.t2.PushFrontNode(e)
+		return
+	}
+
+	if l1 := c.t1.Len() + c.b1.Len(); l1 >= c.capacity {
+		if c.t1.Len() < c.capacity {
+			c.removeGhost(c.b1)
+			if c.full() {
+				c.replace(false)
+			}
+		} else {
+			victim := c.t1.BackNode()
+			c.removeNode(victim)
+			c.evicted(&victim.Value.entry)
+		}
+	} else if total := l1 + c.t2.Len() + c.b2.Len(); total >= c.capacity {
+		if total >= 2*c.capacity {
+			c.removeGhost(c.b2)
+		}
+		if c.full() {
+			c.replace(false)
+		}
+	}
+	c.items[key] = c.t1.PushFrontNode(e)
+}
+
+// Remove removes the key from the cache and returns true if the key is in the cache
+func (c *ARC[K, V]) Remove(key K) bool {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	if g, ok := c.ghosts[key]; ok {
+		if g.frequent {
+			c.b2.Remove(g.node)
+		} else {
+			c.b1.Remove(g.node)
+		}
+		delete(c.ghosts, key)
+	}
+	node, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.removeNode(node)
+	return true
+}
+
+// Len returns the amount of entries in the cache
+func (c *ARC[K, V]) Len() int {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	return len(c.items)
+}
+
+// ratio returns a/b, at least 1
+func ratio(a, b int) int {
+	if a > b {
+		return a / b
+	}
+	return 1
+}
+
+func (c *ARC[K, V]) full() bool {
+	return len(c.items) >= c.capacity
+}
+
+// promote moves the entry to the front of t2
+func (c *ARC[K, V]) promote(node *bidlist.Node[*arcEntry[K, V]]) {
+	e := node.Value
+	if e.frequent {
+		c.t2.MoveToFront(node)
+		return
+	}
+	c.t1.Remove(node)
+	e.frequent = true
+	c.items[e.key] = c.t2.PushFrontNode(e)
+}
+
+// replace evicts the least recently used entry of t1 or t2 according to the target size of t1,
+// and remembers its key in b1 or b2
+func (c *ARC[K, V]) replace(inB2 bool) {
+	var victim *bidlist.Node[*arcEntry[K, V]]
+	if t1Len := c.t1.Len(); t1Len > 0 && (t1Len > c.p || (inB2 && t1Len == c.p) || c.t2.Len() == 0) {
+		victim = c.t1.BackNode()
+	} else {
+		victim = c.t2.BackNode()
+	}
+	c.removeNode(victim)
+	e := victim.Value
+	if e.frequent {
+		c.ghosts[e.key] = ghost[K]{node: c.b2.PushFrontNode(e.key), frequent: true}
+	} else {
+		c.ghosts[e.key] = ghost[K]{node: c.b1.PushFrontNode(e.key)}
+	}
+	c.evicted(&e.entry)
+}
+
+// removeGhost removes the least recently evicted key from the ghost list
+func (c *ARC[K, V]) removeGhost(list *bidlist.List[K]) {
+	if node := list.BackNode(); node != nil {
+		delete(c.ghosts, list.Remove(node))
+	}
+}
+
+// expire removes the node and returns true if it is expired
+func (c *ARC[K, V]) expire(node *bidlist.Node[*arcEntry[K, V]]) bool {
+	if !c.expired(&node.Value.entry) {
+		return false
+	}
+	c.removeNode(node)
+	c.evicted(&node.Value.entry)
+	return true
+}
+
+func (c *ARC[K, V]) removeNode(node *bidlist.Node[*arcEntry[K, V]]) {
+	if node.Value.frequent {
+		c.t2.Remove(node)
+	} else {
+		c.t1.Remove(node)
+	}
+	delete(c.items, node.Value.key)
+}
@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestARCScanResistance(t *testing.T) {
+	c := NewARC[int, int](4)
+	for i := 0; i < 2; i++ {
+		c.Put(i, i)
+		c.Get(i)
+	}
+	// a scan of keys used once doesn't evict the frequently used keys
+	for i := 100; i < 120; i++ {
+		c.Put(i, i)
+	}
+	for i := 0; i < 2; i++ {
+		_, ok := c.Peek(i)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 4, c.Len())
+	assert.Equal(t, 2, c.t2.Len())
+	assert.Equal(t, 2, c.t1.Len())
+}
+
+func TestARCGhost(t *testing.T) {
+	c := NewARC[int, int](2)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Get(2)    // t1: 1, t2: 2
+	c.Put(3, 3) // 1 goes to b1
+	assert.Equal(t, 1, c.b1.Len())
+	_, ok := c.Peek(1)
+	assert.False(t, ok)
+
+	c.Put(1, 10) // hit in b1 increases the target size of t1, puts 1 into t2 and 2 goes to b2
+	assert.Equal(t, 1, c.p)
+	assert.Equal(t, 1, c.t2.Len())
+	assert.Equal(t, 1, c.b2.Len())
+	v, ok := c.Get(1)
+	assert.True(t, ok)
+	assert.Equal(t, 10, v)
+	_, ok = c.Peek(2)
+	assert.False(t, ok)
+
+	c.Put(2, 20) // hit in b2 decreases the target size of t1, 3 goes to b1
+	assert.Equal(t, 0, c.p)
+	assert.Equal(t, 2, c.t2.Len())
+	assert.Equal(t, 0, c.t1.Len())
+	_, ok = c.Peek(3)
+	assert.False(t, ok)
+	assert.True(t, c.ghosts[3].node != nil && !c.ghosts[3].frequent)
+
+	c.Remove(3)
+	c.Remove(1)
+	assert.Equal(t, 1, c.Len())
+	assert.Equal(t, 0, len(c.ghosts))
+}
+
+func TestARCInvariants(t *testing.T) {
+	const capacity = 8
+	c := NewARC[int, int](capacity)
+	for i := 0; i < 5000; i++ {
+		k := (i * 7919) % 37
+		if i%3 == 0 {
+			c.Get(k)
+		} else if i%11 == 0 {
+			c.Remove(k)
+		} else {
+			c.Put(k, i)
+		}
+		assert.LessOrEqual(t, c.Len(), capacity)
+		assert.LessOrEqual(t, c.t1.Len()+c.b1.Len(), capacity)
+		assert.LessOrEqual(t, c.t1.Len()+c.t2.Len()+c.b1.Len()+c.b2.Len(), 2*capacity)
+		assert.Equal(t, len(c.items), c.t1.Len()+c.t2.Len())
+		assert.Equal(t, len(c.ghosts), c.b1.Len()+c.b2.Len())
+		assert.True(t, c.p >= 0 && c.p <= capacity)
+	}
+}
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	gosync "sync"
+	"time"
+
+	"github.com/liyue201/gostl/utils/sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Cache is a fixed-capacity key-value cache, it is implemented by LRU, LFU and ARC
+type Cache[K comparable, V any] interface {
+	// Get returns the value of the key and marks the key as accessed, it counts a hit or a miss
+	Get(key K) (V, bool)
+
+	// Peek returns the value of the key without marking the key as accessed or counting a hit or a miss
+	Peek(key K) (V, bool)
+
+	// Put puts a key-value pair with the default TTL into the cache, an entry is evicted if the cache is full
+	Put(key K, value V)
+
+	// PutWithTTL puts a key-value pair which expires after ttl into the cache, ttl <= 0 means never expire
+	PutWithTTL(key K, value V, ttl time.Duration)
+
+	// Remove removes the key from the cache and returns true if the key is in the cache
+	Remove(key K) bool
+
+	// Len returns the amount of entries in the cache, including the expired entries which haven't been removed yet
+	Len() int
+
+	// Stats returns the hit and miss statistics of the cache
+	Stats() Stats
+}
+
+// Stats holds the statistics of a cache
+type Stats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// HitRate returns the ratio of hits to all Get calls, it returns 0 if Get has never been called
+func (s Stats) HitRate() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+	return float64(s.Hits) / float64(total)
+}
+
+// Options holds the cache's options
+type Options[K comparable, V any] struct {
+	locker  sync.Locker
+	onEvict func(key K, value V)
+	ttl     time.Duration
+}
+
+// Option is a function type used to set Options
+type Option[K comparable, V any] func(option *Options[K, V])
+
+// WithGoroutineSafe is used to set a cache goroutine-safe
+func WithGoroutineSafe[K comparable, V any]() Option[K, V] {
+	return func(option *Options[K, V]) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithEvictCallback sets a callback which is called when an entry is evicted because the cache is full or the
+// entry is expired. It is not called by Remove or when a value is replaced by Put.
+// The callback is called with the cache locked, so it must not call methods of the cache
+func WithEvictCallback[K comparable, V any](onEvict func(key K, value V)) Option[K, V] {
+	return func(option *Options[K, V]) {
+		option.onEvict = onEvict
+	}
+}
+
+// WithTTL sets the default TTL used by Put, entries never expire by default
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(option *Options[K, V]) {
+		option.ttl = ttl
+	}
+}
+
+// entry is a key-value pair in a cache
+type entry[K comparable, V any] struct {
+	key      K
+	value    V
+	expireAt time.Time // zero means never expire
+}
+
+// base holds the fields shared by all kinds of cache
+type base[K comparable, V any] struct {
+	capacity int
+	locker   sync.Locker
+	onEvict  func(key K, value V)
+	ttl      time.Duration
+	stats    Stats
+	now      func() time.Time
+}
+
+func newBase[K comparable, V any](capacity int, opts []Option[K, V]) base[K, V] {
+	option := Options[K, V]{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+	return base[K, V]{
+		capacity: capacity,
+		locker:   option.locker,
+		onEvict:  option.onEvict,
+		ttl:      option.ttl,
+		now:      time.Now,
+	}
+}
+
+// Stats returns the hit and miss statistics of the cache
+func (b *base[K, V]) Stats() Stats {
+	b.locker.RLock()
+	defer b.locker.RUnlock()
+
+	return b.stats
+}
+
+// Capacity returns the capacity of the cache
+func (b *base[K, V]) Capacity() int {
+	return b.capacity
+}
+
+// expireAt returns the expiration time of an entry put now with ttl
+func (b *base[K, V]) expireAt(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return b.now().Add(ttl)
+}
+
+func (b *base[K, V]) expired(e *entry[K, V]) bool {
+	return !e.expireAt.IsZero() && !b.now().Before(e.expireAt)
+}
+
+// evicted counts an eviction and calls the eviction callback
+func (b *base[K, V]) evicted(e *entry[K, V]) {
+	b.stats.Evictions++
+	if b.onEvict != nil {
+		b.onEvict(e.key, e.value)
+	}
+}
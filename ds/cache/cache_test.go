@@ -0,0 +1,171 @@
+package cache
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type clock struct {
+	t time.Time
+}
+
+func (c *clock) now() time.Time {
+	return c.t
+}
+
+func newCaches(capacity int, opts ...Option[int, string]) map[string]Cache[int, string] {
+	return map[string]Cache[int, string]{
+		"LRU": NewLRU[int, string](capacity, opts...),
+		"LFU": NewLFU[int, string](capacity, opts...),
+		"ARC": NewARC[int, string](capacity, opts...),
+	}
+}
+
+// setClock replaces the time source of the cache
+func setClock(c Cache[int, string], clk *clock) {
+	switch v := c.(type) {
+	case *LRU[int, string]:
+		v.now = clk.now
+	case *LFU[int, string]:
+		v.now = clk.now
+	case *ARC[int, string]:
+		v.now = clk.now
+	}
+}
+
+func TestCacheBasic(t *testing.T) {
+	for name, c := range newCaches(3) {
+		t.Run(name, func(t *testing.T) {
+			_, ok := c.Get(1)
+			assert.False(t, ok)
+
+			c.Put(1, "a")
+			c.Put(2, "b")
+			v, ok := c.Get(1)
+			assert.True(t, ok)
+			assert.Equal(t, "a", v)
+			assert.Equal(t, 2, c.Len())
+
+			c.Put(1, "aa")
+			v, ok = c.Peek(1)
+			assert.True(t, ok)
+			assert.Equal(t, "aa", v)
+			assert.Equal(t, 2, c.Len())
+
+			assert.True(t, c.Remove(1))
+			assert.False(t, c.Remove(1))
+			_, ok = c.Peek(1)
+			assert.False(t, ok)
+			assert.Equal(t, 1, c.Len())
+
+			stats := c.Stats()
+			assert.Equal(t, uint64(1), stats.Hits)
+			assert.Equal(t, uint64(1), stats.Misses)
+			assert.Equal(t, uint64(0), stats.Evictions)
+			assert.Equal(t, 0.5, stats.HitRate())
+		})
+	}
+}
+
+func TestCacheCapacity(t *testing.T) {
+	for name, c := range newCaches(10) {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 100; i++ {
+				c.Put(i, "v")
+				c.Get(i / 2)
+				assert.LessOrEqual(t, c.Len(), 10)
+			}
+			assert.Equal(t, 10, c.Len())
+			assert.Equal(t, uint64(90), c.Stats().Evictions)
+		})
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	clk := &clock{t: time.Unix(1000, 0)}
+	for name, c := range newCaches(3, WithTTL[int, string](time.Minute)) {
+		t.Run(name, func(t *testing.T) {
+			setClock(c, clk)
+			c.Put(1, "a")
+			c.PutWithTTL(2, "b", time.Hour)
+			c.PutWithTTL(3, "c", -1)
+
+			clk.t = clk.t.Add(time.Minute)
+			_, ok := c.Peek(1)
+			assert.False(t, ok)
+			_, ok = c.Get(1)
+			assert.False(t, ok)
+			assert.Equal(t, 2, c.Len())
+			assert.Equal(t, uint64(1), c.Stats().Evictions)
+
+			clk.t = clk.t.Add(24 * time.Hour)
+			_, ok = c.Get(2)
+			assert.False(t, ok)
+			v, ok := c.Get(3)
+			assert.True(t, ok)
+			assert.Equal(t, "c", v)
+		})
+	}
+}
+
+func TestCacheEvictCallback(t *testing.T) {
+	clk := &clock{t: time.Unix(1000, 0)}
+	evicted := make(map[int]string)
+	for name, c := range newCaches(2, WithEvictCallback(func(k int, v string) { evicted[k] = v })) {
+		t.Run(name, func(t *testing.T) {
+			for k := range evicted {
+				delete(evicted, k)
+			}
+			setClock(c, clk)
+			c.Put(1, "a")
+			c.Put(2, "b")
+			c.Remove(2)
+			assert.Empty(t, evicted)
+
+			c.PutWithTTL(3, "c", time.Second)
+			c.Put(4, "d")
+			assert.Equal(t, map[int]string{1: "a"}, evicted)
+
+			clk.t = clk.t.Add(time.Second)
+			c.Get(3)
+			assert.Equal(t, map[int]string{1: "a", 3: "c"}, evicted)
+		})
+	}
+}
+
+func TestCacheGoroutineSafe(t *testing.T) {
+	for name, c := range newCaches(100, WithGoroutineSafe[int, string]()) {
+		t.Run(name, func(t *testing.T) {
+			wg := sync.WaitGroup{}
+			for g := 0; g < 8; g++ {
+				wg.Add(1)
+				go func(g int) {
+					defer wg.Done()
+					for i := 0; i < 2000; i++ {
+						k := (i*7 + g) % 300
+						switch i % 4 {
+						case 0, 1:
+							c.Put(k, "v")
+						case 2:
+							c.Get(k)
+						default:
+							c.Remove(k)
+						}
+					}
+				}(g)
+			}
+			wg.Wait()
+			assert.LessOrEqual(t, c.Len(), 100)
+			stats := c.Stats()
+			assert.Equal(t, uint64(8*500), stats.Hits+stats.Misses)
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	assert.Equal(t, 0.0, Stats{}.HitRate())
+	assert.Equal(t, 0.75, Stats{Hits: 3, Misses: 1}.HitRate())
+}
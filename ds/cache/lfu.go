@@ -0,0 +1,155 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/liyue201/gostl/ds/list/bidlist"
+)
+
+var _ Cache[int, int] = (*LFU[int, int])(nil)
+
+// lfuEntry is an entry of LFU which knows the frequency bucket it belongs to
+type lfuEntry[K comparable, V any] struct {
+	entry[K, V]
+	bucket *bidlist.Node[*lfuBucket[K, V]]
+}
+
+// lfuBucket holds the entries with the same access frequency, from the most recently used to the least recently used
+type lfuBucket[K comparable, V any] struct {
+	freq  int
+	items *bidlist.List[*lfuEntry[K, V]]
+}
+
+// LFU is a cache which evicts the least frequently used entry when it is full, the least recently used one
+// is evicted among the entries with the same frequency. All operations take O(1) time
+type LFU[K comparable, V any] struct {
+	base[K, V]
+	buckets *bidlist.List[*lfuBucket[K, V]] // in ascending order of frequency
+	items   map[K]*bidlist.Node[*lfuEntry[K, V]]
+}
+
+// NewLFU creates an LFU cache holding at most capacity entries
+func NewLFU[K comparable, V any](capacity int, opts ...Option[K, V]) *LFU[K, V] {
+	return &LFU[K, V]{
+		base:    newBase(capacity, opts),
+		buckets: bidlist.New[*lfuBucket[K, V]](),
+		items:   make(map[K]*bidlist.Node[*lfuEntry[K, V]]),
+	}
+}
+
+// Get returns the value of the key and increases the frequency of the key
+func (c *LFU[K, V]) Get(key K) (V, bool) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	node, ok := c.items[key]
+	if !ok || c.expire(node) {
+		c.stats.Misses++
+		return *new(V), false
+	}
+	c.stats.Hits++
+	c.touch(node)
+	return node.Value.value, true
+}
+
+// Peek returns the value of the key without increasing the frequency
+func (c *LFU[K, V]) Peek(key K) (V, bool) {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	node, ok := c.items[key]
+	if !ok || c.expired(&node.Value.entry) {
+		return *new(V), false
+	}
+	return node.Value.value, true
+}
+
+// Put puts a key-value pair with the default TTL into the cache
+func (c *LFU[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL puts a key-value pair which expires after ttl into the cache, the least frequently used entry is
+// evicted if the cache is full. Updating an existing key increases its frequency
+func (c *LFU[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	if node, ok := c.items[key]; ok {
+		node.Value.value = value
+		node.Value.expireAt = c.expireAt(ttl)
+		c.touch(node)
+		return
+	}
+	if len(c.items) >= c.capacity {
+		victim := c.buckets.FrontNode().Value.items.BackNode()
+		c.removeNode(victim)
+		c.evicted(&victim.Value.entry)
+	}
+
+	first := c.buckets.FrontNode()
+	if first == nil || first.Value.freq != 1 {
+		first = c.buckets.PushFrontNode(&lfuBucket[K, V]{freq: 1, items: bidlist.New[*lfuEntry[K, V]]()})
+	}
+	e := &lfuEntry[K, V]{
+		entry:  entry[K, V]{key: key, value: value, expireAt: c.expireAt(ttl)},
+		bucket: first,
+	}
+	c.items[key] = first.Value.items.PushFrontNode(e)
+}
+
+// Remove removes the key from the cache and returns true if the key is in the cache
+func (c *LFU[K, V]) Remove(key K) bool {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	node, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.removeNode(node)
+	return true
+}
+
+// Len returns the amount of entries in the cache
+func (c *LFU[K, V]) Len() int {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	return len(c.items)
+}
+
+// touch moves the entry to the bucket with the next frequency
+func (c *LFU[K, V]) touch(node *bidlist.Node[*lfuEntry[K, V]]) {
+	e := node.Value
+	bucket := e.bucket
+	next := bucket.Next()
+	if next == nil || next.Value.freq != bucket.Value.freq+1 {
+		next = c.buckets.InsertAfter(&lfuBucket[K, V]{freq: bucket.Value.freq + 1, items: bidlist.New[*lfuEntry[K, V]]()}, bucket)
+	}
+	bucket.Value.items.Remove(node)
+	if bucket.Value.items.Empty() {
+		c.buckets.Remove(bucket)
+	}
+	e.bucket = next
+	c.items[e.key] = next.Value.items.PushFrontNode(e)
+}
+
+// expire removes the node and returns true if it is expired
+func (c *LFU[K, V]) expire(node *bidlist.Node[*lfuEntry[K, V]]) bool {
+	if !c.expired(&node.Value.entry) {
+		return false
+	}
+	c.removeNode(node)
+	c.evicted(&node.Value.entry)
+	return true
+}
+
+func (c *LFU[K, V]) removeNode(node *bidlist.Node[*lfuEntry[K, V]]) {
+	bucket := node.Value.bucket
+	bucket.Value.items.Remove(node)
+	if bucket.Value.items.Empty() {
+		c.buckets.Remove(bucket)
+	}
+	delete(c.items, node.Value.key)
+}
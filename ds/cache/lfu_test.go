@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLFUEviction(t *testing.T) {
+	c := NewLFU[int, int](3)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+	c.Get(1)
+	c.Get(2)
+	c.Get(3)
+	c.Peek(3)
+	c.Put(4, 4) // 2 and 3 are used twice, 2 is the least recently used one
+
+	_, ok := c.Peek(2)
+	assert.False(t, ok)
+
+	c.Put(5, 5) // evicts 4 which is used once
+	_, ok = c.Peek(4)
+	assert.False(t, ok)
+
+	c.Put(5, 50) // 5 is used twice now
+	c.Get(5)
+	c.Put(6, 6) // evicts 3
+	_, ok = c.Peek(3)
+	assert.False(t, ok)
+	for _, k := range []int{1, 5, 6} {
+		_, ok := c.Peek(k)
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 3, c.Len())
+}
+
+func TestLFUBuckets(t *testing.T) {
+	c := NewLFU[int, int](4)
+	for i := 0; i < 4; i++ {
+		c.Put(i, i)
+		for j := 0; j < i; j++ {
+			c.Get(i)
+		}
+	}
+	var freqs []int
+	for n := c.buckets.FrontNode(); n != nil; n = n.Next() {
+		freqs = append(freqs, n.Value.freq)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4}, freqs)
+
+	c.Remove(1)
+	c.Get(0)
+	freqs = freqs[:0]
+	for n := c.buckets.FrontNode(); n != nil; n = n.Next() {
+		freqs = append(freqs, n.Value.freq)
+	}
+	assert.Equal(t, []int{2, 3, 4}, freqs)
+	assert.Equal(t, 0, c.buckets.FrontNode().Value.items.Front().value)
+}
@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"time"
+
+	"github.com/liyue201/gostl/ds/list/bidlist"
+)
+
+var _ Cache[int, int] = (*LRU[int, int])(nil)
+
+// LRU is a cache which evicts the least recently used entry when it is full
+type LRU[K comparable, V any] struct {
+	base[K, V]
+	list  *bidlist.List[*entry[K, V]] // from the most recently used to the least recently used
+	items map[K]*bidlist.Node[*entry[K, V]]
+}
+
+// NewLRU creates an LRU cache holding at most capacity entries
+func NewLRU[K comparable, V any](capacity int, opts ...Option[K, V]) *LRU[K, V] {
+	return &LRU[K, V]{
+		base:  newBase(capacity, opts),
+		list:  bidlist.New[*entry[K, V]](),
+		items: make(map[K]*bidlist.Node[*entry[K, V]]),
+	}
+}
+
+// Get returns the value of the key and marks the key as the most recently used
+func (c *LRU[K, V]) Get(key K) (V, bool) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	node, ok := c.items[key]
+	if !ok || c.expire(node) {
+		c.stats.Misses++
+		return *new(V), false
+	}
+	c.stats.Hits++
+	c.list.MoveToFront(node)
+	return node.Value.value, true
+}
+
+// Peek returns the value of the key without updating the recentness
+func (c *LRU[K, V]) Peek(key K) (V, bool) {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	node, ok := c.items[key]
+	if !ok || c.expired(node.Value) {
+		return *new(V), false
+	}
+	return node.Value.value, true
+}
+
+// Put puts a key-value pair with the default TTL into the cache
+func (c *LRU[K, V]) Put(key K, value V) {
+	c.PutWithTTL(key, value, c.ttl)
+}
+
+// PutWithTTL puts a key-value pair which expires after ttl into the cache, the least recently used entry is
+// evicted if the cache is full
+func (c *LRU[K, V]) PutWithTTL(key K, value V, ttl time.Duration) {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	if node, ok := c.items[key]; ok {
+		node.Value.value = value
+		node.Value.expireAt = c.expireAt(ttl)
+		c.list.MoveToFront(node)
+		return
+	}
+	if c.list.Len() >= c.capacity {
+		back := c.list.BackNode()
+		c.removeNode(back)
+		c.evicted(back.Value)
+	}
+	e := &entry[K, V]{key: key, value: value, expireAt: c.expireAt(ttl)}
+	c.items[key] = c.list.PushFrontNode(e)
+}
+
+// Remove removes the key from the cache and returns true if the key is in the cache
+func (c *LRU[K, V]) Remove(key K) bool {
+	c.locker.Lock()
+	defer c.locker.Unlock()
+
+	node, ok := c.items[key]
+	if !ok {
+		return false
+	}
+	c.removeNode(node)
+	return true
+}
+
+// Len returns the amount of entries in the cache
+func (c *LRU[K, V]) Len() int {
+	c.locker.RLock()
+	defer c.locker.RUnlock()
+
+	return c.list.Len()
+}
+
+// expire removes the node and returns true if it is expired
+func (c *LRU[K, V]) expire(node *bidlist.Node[*entry[K, V]]) bool {
+	if !c.expired(node.Value) {
+		return false
+	}
+	c.removeNode(node)
+	c.evicted(node.Value)
+	return true
+}
+
+func (c *LRU[K, V]) removeNode(node *bidlist.Node[*entry[K, V]]) {
+	delete(c.items, node.Value.key)
+	c.list.Remove(node)
+}
@@ -0,0 +1,42 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUEviction(t *testing.T) {
+	c := NewLRU[int, int](3)
+	assert.Equal(t, 3, c.Capacity())
+	c.Put(1, 1)
+	c.Put(2, 2)
+	c.Put(3, 3)
+	c.Get(1)
+	c.Peek(2)
+	c.Put(4, 4) // evicts 2
+
+	_, ok := c.Peek(2)
+	assert.False(t, ok)
+	for _, k := range []int{1, 3, 4} {
+		v, ok := c.Peek(k)
+		assert.True(t, ok)
+		assert.Equal(t, k, v)
+	}
+
+	c.Put(3, 30) // 3 becomes the most recently used
+	c.Put(5, 5)  // evicts 1
+	_, ok = c.Peek(1)
+	assert.False(t, ok)
+	v, _ := c.Peek(3)
+	assert.Equal(t, 30, v)
+}
+
+func TestLRUMinCapacity(t *testing.T) {
+	c := NewLRU[int, int](0)
+	c.Put(1, 1)
+	c.Put(2, 2)
+	assert.Equal(t, 1, c.Len())
+	_, ok := c.Peek(2)
+	assert.True(t, ok)
+}
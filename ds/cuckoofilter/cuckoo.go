@@ -0,0 +1,359 @@
+package cuckoo
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/fnv"
+	"math/rand"
+	gosync "sync"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/liyue201/gostl/utils/sync"
+)
+
+// Default parameters of CuckooFilter
+const (
+	DefaultFingerprintBits = 8
+	DefaultBucketSize      = 4
+	DefaultMaxKicks        = 500
+)
+
+// ErrorFull is returned by Insert if a value can't be inserted because the filter is full
+var ErrorFull = errors.New("cuckoo filter is full")
+
+var defaultLocker sync.FakeLocker
+
+// Options holds CuckooFilter's options
+type Options struct {
+	locker          sync.Locker
+	fingerprintBits uint8
+	bucketSize      uint8
+	maxKicks        int
+}
+
+// Option is a function type used to set Options
+type Option func(opt *Options)
+
+// WithGoroutineSafe is used to config a CuckooFilter with goroutine-safe
+func WithGoroutineSafe() Option {
+	return func(opt *Options) {
+		opt.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithFingerprintBits sets the size in bits of fingerprints, it must be in range [2, 16].
+// A larger fingerprint means a lower false positive rate and more memory. The default is DefaultFingerprintBits
+func WithFingerprintBits(bits uint8) Option {
+	return func(opt *Options) {
+		if bits >= 2 && bits <= 16 {
+			opt.fingerprintBits = bits
+		}
+	}
+}
+
+// WithBucketSize sets the amount of fingerprints held by a bucket, it must be in range [1, 8].
+// The default is DefaultBucketSize
+func WithBucketSize(size uint8) Option {
+	return func(opt *Options) {
+		if size >= 1 && size <= 8 {
+			opt.bucketSize = size
+		}
+	}
+}
+
+// WithMaxKicks sets the max amount of relocations tried by Insert before the filter is regarded as full.
+// The default is DefaultMaxKicks
+func WithMaxKicks(n int) Option {
+	return func(opt *Options) {
+		if n > 0 {
+			opt.maxKicks = n
+		}
+	}
+}
+
+func newOptions(opts []Option) Options {
+	opt := Options{
+		locker:          defaultLocker,
+		fingerprintBits: DefaultFingerprintBits,
+		bucketSize:      DefaultBucketSize,
+		maxKicks:        DefaultMaxKicks,
+	}
+	for _, o := range opts {
+		o(&opt)
+	}
+	return opt
+}
+
+// victim is a fingerprint which could not be placed by Insert, it is kept so that it is not lost
+type victim struct {
+	used        bool
+	index       uint64
+	fingerprint uint16
+}
+
+// CuckooFilter is an implementation of cuckoo filter, a probabilistic set supporting deletion.
+// Each value is represented by a fingerprint stored in one of its two candidate buckets,
+// and the fingerprints are packed in a bit array
+type CuckooFilter struct {
+	bits       uint8  // fingerprint size in bits
+	bucketSize uint8  // fingerprints per bucket
+	buckets    uint64 // amount of buckets, a power of 2
+	maxKicks   int
+	table      []uint64
+	count      uint64
+	victim     victim
+	rand       *rand.Rand
+	locker     sync.Locker
+}
+
+// New creates a new CuckooFilter able to hold about capacity values
+func New(capacity uint64, opts ...Option) *CuckooFilter {
+	opt := newOptions(opts)
+	buckets := uint64(1)
+	for buckets*uint64(opt.bucketSize) < capacity {
+		buckets <<= 1
+	}
+	cf := &CuckooFilter{
+		bits:       opt.fingerprintBits,
+		bucketSize: opt.bucketSize,
+		buckets:    buckets,
+		maxKicks:   opt.maxKicks,
+		locker:     opt.locker,
+	}
+	cf.init()
+	return cf
+}
+
+func (cf *CuckooFilter) init() {
+	slots := cf.buckets * uint64(cf.bucketSize)
+	cf.table = make([]uint64, (slots*uint64(cf.bits)+63)/64)
+	cf.rand = rand.New(rand.NewSource(int64(cf.buckets)))
+}
+
+// get returns the fingerprint at slot j of bucket i, 0 means empty
+func (cf *CuckooFilter) get(i uint64, j int) uint16 {
+	off := (i*uint64(cf.bucketSize) + uint64(j)) * uint64(cf.bits)
+	w, sh := off/64, off%64
+	v := cf.table[w] >> sh
+	if sh+uint64(cf.bits) > 64 {
+		v |= cf.table[w+1] << (64 - sh)
+	}
+	return uint16(v & (1<<cf.bits - 1))
+}
+
+func (cf *CuckooFilter) set(i uint64, j int, fp uint16) {
+	off := (i*uint64(cf.bucketSize) + uint64(j)) * uint64(cf.bits)
+	w, sh := off/64, off%64
+	mask := uint64(1)<<cf.bits - 1
+	cf.table[w] = cf.table[w]&^(mask<<sh) | uint64(fp)<<sh
+	if sh+uint64(cf.bits) > 64 {
+		rest := 64 - sh
+		cf.table[w+1] = cf.table[w+1]&^(mask>>rest) | uint64(fp)>>rest
+	}
+}
+
+// indexAndFingerprint returns the first candidate bucket and the non-zero fingerprint of val
+func (cf *CuckooFilter) indexAndFingerprint(val string) (uint64, uint16) {
+	h := fnv.New64a()
+	h.Write([]byte(val))
+	sum := h.Sum64()
+	fp := uint16(sum>>32%(1<<cf.bits-1)) + 1
+	return sum & (cf.buckets - 1), fp
+}
+
+// altIndex returns the other candidate bucket of a fingerprint in bucket i, altIndex(altIndex(i, fp), fp) == i
+func (cf *CuckooFilter) altIndex(i uint64, fp uint16) uint64 {
+	return (i ^ uint64(fp)*0x5bd1e995) & (cf.buckets - 1)
+}
+
+func (cf *CuckooFilter) insertInto(i uint64, fp uint16) bool {
+	for j := 0; j < int(cf.bucketSize); j++ {
+		if cf.get(i, j) == 0 {
+			cf.set(i, j, fp)
+			return true
+		}
+	}
+	return false
+}
+
+func (cf *CuckooFilter) deleteFrom(i uint64, fp uint16) bool {
+	for j := 0; j < int(cf.bucketSize); j++ {
+		if cf.get(i, j) == fp {
+			cf.set(i, j, 0)
+			return true
+		}
+	}
+	return false
+}
+
+func (cf *CuckooFilter) bucketContains(i uint64, fp uint16) bool {
+	for j := 0; j < int(cf.bucketSize); j++ {
+		if cf.get(i, j) == fp {
+			return true
+		}
+	}
+	return false
+}
+
+// Insert inserts val into the CuckooFilter, it returns ErrorFull if the filter is full.
+// If no slot is found after max kicks, the last kicked out fingerprint is kept aside so no value is lost,
+// and the following inserts fail until a value is deleted.
+// A value can be inserted more than once, and should be deleted the same times
+func (cf *CuckooFilter) Insert(val string) error {
+	cf.locker.Lock()
+	defer cf.locker.Unlock()
+
+	if cf.victim.used {
+		return ErrorFull
+	}
+	i, fp := cf.indexAndFingerprint(val)
+	cf.place(i, fp)
+	cf.count++
+	return nil
+}
+
+// place puts fp into bucket i or its alternate bucket, relocating other fingerprints randomly if both are full.
+// If no slot is found after max kicks, the last kicked out fingerprint becomes the victim
+func (cf *CuckooFilter) place(i uint64, fp uint16) {
+	if cf.insertInto(i, fp) || cf.insertInto(cf.altIndex(i, fp), fp) {
+		return
+	}
+	if cf.rand.Intn(2) == 0 {
+		i = cf.altIndex(i, fp)
+	}
+	for n := 0; n < cf.maxKicks; n++ {
+		j := cf.rand.Intn(int(cf.bucketSize))
+		old := cf.get(i, j)
+		cf.set(i, j, fp)
+		fp = old
+		i = cf.altIndex(i, fp)
+		if cf.insertInto(i, fp) {
+			return
+		}
+	}
+	cf.victim = victim{used: true, index: i, fingerprint: fp}
+}
+
+// Lookup returns true if val is (high probability) in the CuckooFilter, otherwise returns false
+func (cf *CuckooFilter) Lookup(val string) bool {
+	cf.locker.RLock()
+	defer cf.locker.RUnlock()
+
+	i, fp := cf.indexAndFingerprint(val)
+	i2 := cf.altIndex(i, fp)
+	if cf.victim.used && cf.victim.fingerprint == fp && (cf.victim.index == i || cf.victim.index == i2) {
+		return true
+	}
+	return cf.bucketContains(i, fp) || cf.bucketContains(i2, fp)
+}
+
+// Delete deletes val from the CuckooFilter and returns true if val is (high probability) in the filter.
+// Only delete values which have been inserted, otherwise another value may be deleted
+func (cf *CuckooFilter) Delete(val string) bool {
+	cf.locker.Lock()
+	defer cf.locker.Unlock()
+
+	i, fp := cf.indexAndFingerprint(val)
+	i2 := cf.altIndex(i, fp)
+	switch {
+	case cf.deleteFrom(i, fp) || cf.deleteFrom(i2, fp):
+	case cf.victim.used && cf.victim.fingerprint == fp && (cf.victim.index == i || cf.victim.index == i2):
+		cf.victim.used = false
+	default:
+		return false
+	}
+	cf.count--
+	// a slot is freed, try to place the victim again
+	if v := cf.victim; v.used {
+		cf.victim.used = false
+		cf.place(v.index, v.fingerprint)
+	}
+	return true
+}
+
+// Count returns the amount of values in the CuckooFilter
+func (cf *CuckooFilter) Count() uint64 {
+	cf.locker.RLock()
+	defer cf.locker.RUnlock()
+
+	return cf.count
+}
+
+// LoadFactor returns the ratio of used slots to all slots
+func (cf *CuckooFilter) LoadFactor() float64 {
+	cf.locker.RLock()
+	defer cf.locker.RUnlock()
+
+	return float64(cf.count) / float64(cf.buckets*uint64(cf.bucketSize))
+}
+
+// Reset removes all values in the CuckooFilter
+func (cf *CuckooFilter) Reset() {
+	cf.locker.Lock()
+	defer cf.locker.Unlock()
+
+	for i := range cf.table {
+		cf.table[i] = 0
+	}
+	cf.count = 0
+	cf.victim = victim{}
+}
+
+const headerSize = 1 + 1 + 4 + 8 + 8 + 1 + 8 + 2
+
+// Data returns the data of the CuckooFilter, it can be used to create a new CuckooFilter by using function 'NewFromData'
+func (cf *CuckooFilter) Data() []byte {
+	cf.locker.RLock()
+	defer cf.locker.RUnlock()
+
+	data := make([]byte, headerSize+len(cf.table)*8)
+	data[0] = cf.bits
+	data[1] = cf.bucketSize
+	binary.LittleEndian.PutUint32(data[2:], uint32(cf.maxKicks))
+	binary.LittleEndian.PutUint64(data[6:], cf.buckets)
+	binary.LittleEndian.PutUint64(data[14:], cf.count)
+	if cf.victim.used {
+		data[22] = 1
+	}
+	binary.LittleEndian.PutUint64(data[23:], cf.victim.index)
+	binary.LittleEndian.PutUint16(data[31:], cf.victim.fingerprint)
+	for i, w := range cf.table {
+		binary.LittleEndian.PutUint64(data[headerSize+i*8:], w)
+	}
+	return data
+}
+
+// NewFromData creates a new CuckooFilter from data generated by function 'Data()',
+// the fingerprint size, bucket size and max kicks are read from data
+func NewFromData(data []byte, opts ...Option) (*CuckooFilter, error) {
+	if len(data) < headerSize {
+		return nil, codec.ErrorCorruptData
+	}
+	opt := newOptions(opts)
+	cf := &CuckooFilter{
+		bits:       data[0],
+		bucketSize: data[1],
+		maxKicks:   int(binary.LittleEndian.Uint32(data[2:])),
+		buckets:    binary.LittleEndian.Uint64(data[6:]),
+		count:      binary.LittleEndian.Uint64(data[14:]),
+		victim: victim{
+			used:        data[22] == 1,
+			index:       binary.LittleEndian.Uint64(data[23:]),
+			fingerprint: binary.LittleEndian.Uint16(data[31:]),
+		},
+		locker: opt.locker,
+	}
+	if cf.bits < 2 || cf.bits > 16 || cf.bucketSize < 1 || cf.bucketSize > 8 || cf.maxKicks <= 0 ||
+		cf.buckets == 0 || cf.buckets&(cf.buckets-1) != 0 || cf.victim.index >= cf.buckets {
+		return nil, codec.ErrorCorruptData
+	}
+	words := (cf.buckets*uint64(cf.bucketSize)*uint64(cf.bits) + 63) / 64
+	if uint64(len(data)-headerSize) != words*8 {
+		return nil, codec.ErrorCorruptData
+	}
+	cf.init()
+	for i := range cf.table {
+		cf.table[i] = binary.LittleEndian.Uint64(data[headerSize+i*8:])
+	}
+	return cf, nil
+}
@@ -0,0 +1,151 @@
+package cuckoo
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCuckooFilter(t *testing.T) {
+	cf := New(1000, WithGoroutineSafe())
+	assert.Equal(t, uint64(256), cf.buckets)
+	assert.Equal(t, uint64(0), cf.Count())
+
+	for i := 0; i < 900; i++ {
+		assert.Nil(t, cf.Insert(strconv.Itoa(i)))
+	}
+	assert.Equal(t, uint64(900), cf.Count())
+	assert.InDelta(t, 900.0/1024, cf.LoadFactor(), 1e-9)
+	for i := 0; i < 900; i++ {
+		assert.True(t, cf.Lookup(strconv.Itoa(i)))
+	}
+
+	falsePositives := 0
+	for i := 900; i < 20900; i++ {
+		if cf.Lookup(strconv.Itoa(i)) {
+			falsePositives++
+		}
+	}
+	// the bound is 2 * bucketSize / 2^8
+	assert.Less(t, float64(falsePositives)/20000, 0.04)
+
+	for i := 0; i < 900; i += 2 {
+		assert.True(t, cf.Delete(strconv.Itoa(i)))
+	}
+	assert.Equal(t, uint64(450), cf.Count())
+	for i := 1; i < 900; i += 2 {
+		assert.True(t, cf.Lookup(strconv.Itoa(i)))
+	}
+
+	cf.Reset()
+	assert.Equal(t, uint64(0), cf.Count())
+	assert.False(t, cf.Lookup("1"))
+	assert.False(t, cf.Delete("1"))
+}
+
+func TestCuckooFilterDuplicates(t *testing.T) {
+	cf := New(100)
+	assert.Nil(t, cf.Insert("a"))
+	assert.Nil(t, cf.Insert("a"))
+	assert.Equal(t, uint64(2), cf.Count())
+	assert.True(t, cf.Delete("a"))
+	assert.True(t, cf.Lookup("a"))
+	assert.True(t, cf.Delete("a"))
+	assert.False(t, cf.Lookup("a"))
+	assert.False(t, cf.Delete("a"))
+}
+
+func TestCuckooFilterFull(t *testing.T) {
+	cf := New(64, WithMaxKicks(50))
+	var err error
+	n := 0
+	for ; n < 1000; n++ {
+		if err = cf.Insert(strconv.Itoa(n)); err != nil {
+			break
+		}
+	}
+	assert.Equal(t, ErrorFull, err)
+	assert.True(t, cf.victim.used)
+	assert.LessOrEqual(t, cf.Count(), uint64(65))
+	assert.Greater(t, cf.Count(), uint64(48))
+	// no value is lost
+	for i := 0; i < n; i++ {
+		assert.True(t, cf.Lookup(strconv.Itoa(i)))
+	}
+
+	// deleting frees a slot for the victim
+	assert.True(t, cf.Delete("0"))
+	assert.False(t, cf.victim.used)
+	for i := 1; i < n; i++ {
+		assert.True(t, cf.Lookup(strconv.Itoa(i)))
+	}
+	assert.Nil(t, cf.Insert(strconv.Itoa(n)))
+}
+
+func TestCuckooFilterOptions(t *testing.T) {
+	for _, bits := range []uint8{2, 5, 8, 12, 13, 16} {
+		for _, size := range []uint8{1, 2, 4, 8} {
+			cf := New(500, WithFingerprintBits(bits), WithBucketSize(size))
+			assert.Equal(t, bits, cf.bits)
+			assert.Equal(t, size, cf.bucketSize)
+			n := 0
+			for ; n < 500; n++ {
+				if cf.Insert(strconv.Itoa(n)) != nil {
+					break
+				}
+			}
+			assert.Greater(t, n, 200)
+			for i := 0; i < n; i++ {
+				assert.True(t, cf.Lookup(strconv.Itoa(i)))
+			}
+			for i := 0; i < n; i++ {
+				assert.True(t, cf.Delete(strconv.Itoa(i)))
+			}
+			assert.Equal(t, uint64(0), cf.Count())
+			for _, w := range cf.table {
+				assert.Equal(t, uint64(0), w)
+			}
+		}
+	}
+
+	// invalid options are ignored
+	cf := New(10, WithFingerprintBits(1), WithFingerprintBits(17), WithBucketSize(0), WithBucketSize(9), WithMaxKicks(0))
+	assert.Equal(t, uint8(DefaultFingerprintBits), cf.bits)
+	assert.Equal(t, uint8(DefaultBucketSize), cf.bucketSize)
+	assert.Equal(t, DefaultMaxKicks, cf.maxKicks)
+}
+
+func TestCuckooFilterData(t *testing.T) {
+	cf := New(300, WithFingerprintBits(12), WithBucketSize(2), WithMaxKicks(100))
+	for i := 0; i < 200; i++ {
+		assert.Nil(t, cf.Insert(strconv.Itoa(i)))
+	}
+	data := cf.Data()
+
+	other, err := NewFromData(data, WithGoroutineSafe())
+	assert.Nil(t, err)
+	assert.Equal(t, cf.bits, other.bits)
+	assert.Equal(t, cf.bucketSize, other.bucketSize)
+	assert.Equal(t, cf.maxKicks, other.maxKicks)
+	assert.Equal(t, cf.Count(), other.Count())
+	assert.Equal(t, cf.table, other.table)
+	for i := 0; i < 200; i++ {
+		assert.True(t, other.Lookup(strconv.Itoa(i)))
+	}
+	assert.Equal(t, data, other.Data())
+
+	_, err = NewFromData(data[:len(data)-1])
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	_, err = NewFromData(data[:10])
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	bad := append([]byte{}, data...)
+	bad[0] = 17
+	_, err = NewFromData(bad)
+	assert.Equal(t, codec.ErrorCorruptData, err)
+	bad = append([]byte{}, data...)
+	bad[6] = 3
+	_, err = NewFromData(bad)
+	assert.Equal(t, codec.ErrorCorruptData, err)
+}
@@ -3,6 +3,8 @@ package deque
 import (
 	"errors"
 	"fmt"
+
+	"github.com/liyue201/gostl/utils/codec"
 )
 
 // Constants definition
@@ -15,6 +17,22 @@ var (
 	ErrOutOfRange = errors.New("out off range")
 )
 
+// Options holds the Deque's options
+type Options struct {
+	codec any
+}
+
+// Option is a function type used to set Options
+type Option func(option *Options)
+
+// WithCodec sets the codec used to encode values in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of values, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithCodec[T any](c codec.Codec[T]) Option {
+	return func(option *Options) {
+		option.codec = c
+	}
+}
+
 // Deque is double-ended queue supports efficient data insertion from the head and tail, random access and iterator access.
 type Deque[T any] struct {
 	pool  *Pool[T]
@@ -22,13 +40,19 @@ type Deque[T any] struct {
 	begin int
 	end   int
 	size  int
+	codec codec.Codec[T]
 }
 
 // New creates a new deque
-func New[T any]() *Deque[T] {
+func New[T any](opts ...Option) *Deque[T] {
+	option := Options{}
+	for _, opt := range opts {
+		opt(&option)
+	}
 	dq := &Deque[T]{
-		pool: newPool[T](),
-		segs: make([]*Segment[T], 0),
+		pool:  newPool[T](),
+		segs:  make([]*Segment[T], 0),
+		codec: codec.Get[T](option.codec),
 	}
 	return dq
 }
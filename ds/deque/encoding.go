@@ -0,0 +1,82 @@
+package deque
+
+import (
+	"encoding/json"
+
+	"github.com/liyue201/gostl/utils/codec"
+)
+
+// MarshalBinary encodes the deque with its codec, it implements encoding.BinaryMarshaler
+func (d *Deque[T]) MarshalBinary() ([]byte, error) {
+	if err := codec.Check(d.codec); err != nil {
+		return nil, err
+	}
+	var err error
+	buf := codec.AppendUvarint(nil, uint64(d.size))
+	for i := 0; i < d.size; i++ {
+		if buf, err = d.codec.Encode(buf, d.At(i)); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the deque with the data returned by MarshalBinary,
+// it implements encoding.BinaryUnmarshaler. Note that the deque must be created by New
+func (d *Deque[T]) UnmarshalBinary(data []byte) error {
+	if err := codec.Check(d.codec); err != nil {
+		return err
+	}
+	n, data, err := codec.ReadLen(data)
+	if err != nil {
+		return err
+	}
+	values := make([]T, n)
+	for i := 0; i < n; i++ {
+		if values[i], data, err = d.codec.Decode(data); err != nil {
+			return err
+		}
+	}
+	if len(data) != 0 {
+		return codec.ErrorCorruptData
+	}
+	d.reset(values)
+	return nil
+}
+
+// MarshalJSON encodes the deque into a JSON array, it implements json.Marshaler
+func (d *Deque[T]) MarshalJSON() ([]byte, error) {
+	values := make([]T, 0, d.size)
+	for i := 0; i < d.size; i++ {
+		values = append(values, d.At(i))
+	}
+	return json.Marshal(values)
+}
+
+// UnmarshalJSON replaces the content of the deque with the data returned by MarshalJSON, it implements json.Unmarshaler.
+// Note that the deque must be created by New
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	d.reset(values)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, it is the same as MarshalBinary
+func (d *Deque[T]) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, it is the same as UnmarshalBinary
+func (d *Deque[T]) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+func (d *Deque[T]) reset(values []T) {
+	d.Clear()
+	for _, value := range values {
+		d.PushBack(value)
+	}
+}
@@ -0,0 +1,62 @@
+package deque
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDequeBinary(t *testing.T) {
+	q := New[int](WithCodec[int](codec.Integer[int]{}))
+	for i := 0; i < 1000; i++ {
+		q.PushBack(i)
+	}
+	q.PushFront(-1)
+	data, err := q.MarshalBinary()
+	assert.Nil(t, err)
+
+	q2 := New[int](WithCodec[int](codec.Integer[int]{}))
+	q2.PushBack(5000)
+	assert.Nil(t, q2.UnmarshalBinary(data))
+	assert.Equal(t, 1001, q2.Size())
+	for i := 0; i < 1001; i++ {
+		assert.Equal(t, i-1, q2.At(i))
+	}
+
+	assert.NotNil(t, q2.UnmarshalBinary(data[:3]))
+}
+
+func TestDequeJSONAndGob(t *testing.T) {
+	q := New[string]()
+	q.PushBack("b")
+	q.PushFront("a")
+
+	data, err := json.Marshal(q)
+	assert.Nil(t, err)
+	assert.Equal(t, `["a","b"]`, string(data))
+
+	q2 := New[string]()
+	assert.Nil(t, json.Unmarshal(data, q2))
+	assert.Equal(t, "[a b]", q2.String())
+
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(q))
+	q3 := New[string]()
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(q3))
+	assert.Equal(t, "[a b]", q3.String())
+}
+
+func TestDequeWrongCodec(t *testing.T) {
+	q := New[int](WithCodec[string](codec.String[string]{}))
+	_, err := q.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	q.PushBack(1)
+	_, err = q.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, q.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+	assert.Equal(t, 1, q.Size())
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package deque
+
+import "iter"
+
+// All returns an iterator over index-value pairs in the deque, from front to back
+func (d *Deque[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(i, d.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs in the deque, from back to front
+func (d *Deque[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := d.size - 1; i >= 0; i-- {
+			if !yield(i, d.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the deque, from front to back
+func (d *Deque[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(d.At(i)) {
+				return
+			}
+		}
+	}
+}
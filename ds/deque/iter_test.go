@@ -0,0 +1,44 @@
+//go:build go1.23
+
+package deque
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDequeIterSeq(t *testing.T) {
+	q := New[int]()
+	for i := 0; i < 1000; i++ {
+		q.PushBack(i)
+	}
+	q.PushFront(-1)
+
+	n := 0
+	for i, val := range q.All() {
+		assert.Equal(t, n, i)
+		assert.Equal(t, i-1, val)
+		n++
+	}
+	assert.Equal(t, 1001, n)
+
+	for i, val := range q.Backward() {
+		n--
+		assert.Equal(t, n, i)
+		assert.Equal(t, i-1, val)
+		if i < 500 {
+			break
+		}
+	}
+	assert.Equal(t, 499, n)
+
+	values := make([]int, 0)
+	for val := range q.Values() {
+		if len(values) == 3 {
+			break
+		}
+		values = append(values, val)
+	}
+	assert.Equal(t, []int{-1, 0, 1}, values)
+}
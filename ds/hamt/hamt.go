@@ -2,11 +2,8 @@ package hamt
 
 import (
 	"errors"
-	"github.com/liyue201/gostl/utils/sync"
 	"github.com/liyue201/gostl/utils/visitor"
-	"hash/fnv"
 	"math/bits"
-	gosync "sync"
 )
 
 // Some constants
@@ -22,25 +19,6 @@ var ErrorNotFound = errors.New("not found")
 // Key is a redefinition of []byte
 type Key []byte
 
-var (
-	defaultLocker sync.FakeLocker
-)
-
-// Options holds Hamt's options
-type Options struct {
-	locker sync.Locker
-}
-
-// Option is a function type used to set Options
-type Option func(option *Options)
-
-// WithGoroutineSafe is used to config a Hamt with goroutine-safe
-func WithGoroutineSafe() Option {
-	return func(option *Options) {
-		option.locker = &gosync.RWMutex{}
-	}
-}
-
 // Entry is a tree node interface
 type Entry interface {
 	// Type returns the node type
@@ -50,54 +28,49 @@ type Entry interface {
 	BitPosNum(depth int) uint64
 }
 
-// BitmapNode defines Hamt's bitmap node
-type BitmapNode[T any] struct {
+// mapBitmapNode is the bitmap node of Map
+type mapBitmapNode[K, V any] struct {
 	bitmap   uint64
 	children []Entry
-	pos      uint8 //position in parent array, in range [0, 64)
+	pos      uint8      //position in parent array, in range [0, 64)
+	edit     *editToken //owner of the node in a TransientHamt, nil for other nodes
 }
 
-// KvPair is a list node with actually value
-type KvPair[T any] struct {
-	key   Key
-	value T
-	next  *KvPair[T]
+// mapKvPair is a list node of Map with actually value
+type mapKvPair[K, V any] struct {
+	key   K
+	value V
+	next  *mapKvPair[K, V]
 }
 
-//KvNode is Hamt's key-value node
-type KvNode[T any] struct {
+// mapKvNode is the key-value node of Map
+type mapKvNode[K, V any] struct {
 	hash   uint64
-	kvList *KvPair[T]
-}
-
-// Hamt is an implementation of hash-array-mapped-trie
-type Hamt[T any] struct {
-	root   BitmapNode[T]
-	locker sync.Locker
+	kvList *mapKvPair[K, V]
 }
 
 // Type returns the node type
-func (h *BitmapNode[T]) Type() int {
+func (h *mapBitmapNode[K, V]) Type() int {
 	return BITMAP_NODE
 }
 
 // BitPosNum returns the number from a bit position
-func (h *BitmapNode[T]) BitPosNum(int) uint64 {
+func (h *mapBitmapNode[K, V]) BitPosNum(int) uint64 {
 	return uint64(1) << h.pos
 }
 
 // Index returns the index of a bitPos int bitmap
-func (h *BitmapNode[T]) Index(bitPos uint64) int {
+func (h *mapBitmapNode[K, V]) Index(bitPos uint64) int {
 	return bits.OnesCount64((bitPos - 1) & h.bitmap)
 }
 
-func (h *BitmapNode[T]) insert(depth int, hash uint64, kv *KvPair[T]) {
+func (h *mapBitmapNode[K, V]) insert(depth int, hash uint64, kv *mapKvPair[K, V], equal Equaler[K]) {
 	pos := pos(hash, depth) //hash in current node's position
 	bitPos := bitPos(pos)   //hash in current bitmap's position in bit
 	if bitPos&h.bitmap == 0 {
 		h.bitmap |= bitPos
 		newChildren := make([]Entry, len(h.children)+1)
-		kvNode := &KvNode[T]{
+		kvNode := &mapKvNode[K, V]{
 			hash:   hash,
 			kvList: kv,
 		}
@@ -112,10 +85,10 @@ func (h *BitmapNode[T]) insert(depth int, hash uint64, kv *KvPair[T]) {
 		index := h.Index(bitPos)
 		entry := h.children[index]
 		if entry.Type() == KV_NODE {
-			kvNode := entry.(*KvNode[T])
+			kvNode := entry.(*mapKvNode[K, V])
 			if kvNode.hash == hash {
 				for iter := kvNode.kvList; iter != nil; iter = iter.next {
-					if string(iter.key) == string(kv.key) {
+					if equal(iter.key, kv.key) {
 						iter.value = kv.value
 						return
 					}
@@ -123,60 +96,64 @@ func (h *BitmapNode[T]) insert(depth int, hash uint64, kv *KvPair[T]) {
 				kv.next = kvNode.kvList
 				kvNode.kvList = kv
 			} else {
-				bitmapNode := &BitmapNode[T]{
+				bitmapNode := &mapBitmapNode[K, V]{
 					pos: pos,
 				}
-				bitmapNode.insert(depth+1, kvNode.hash, kvNode.kvList)
-				bitmapNode.insert(depth+1, hash, kv)
+				bitmapNode.insert(depth+1, kvNode.hash, kvNode.kvList, equal)
+				bitmapNode.insert(depth+1, hash, kv, equal)
 				h.children[index] = bitmapNode
 			}
 		} else {
-			entry.(*BitmapNode[T]).insert(depth+1, hash, kv)
+			entry.(*mapBitmapNode[K, V]).insert(depth+1, hash, kv, equal)
 		}
 	}
 }
 
-func (h *BitmapNode[T]) find(depth int, hash uint64, key Key) (T, error) {
+func (h *mapBitmapNode[K, V]) find(depth int, hash uint64, key K, equal Equaler[K]) (V, error) {
 	pos := pos(hash, depth) //hash in current node's position
 	bitPos := bitPos(pos)   //hash in current bitmap's position in bit
 	if bitPos&h.bitmap == 0 {
-		return *new(T), ErrorNotFound
+		return *new(V), ErrorNotFound
 	}
 	index := h.Index(bitPos)
 	entry := h.children[index]
 	if entry.Type() == KV_NODE {
-		kvNode := entry.(*KvNode[T])
+		kvNode := entry.(*mapKvNode[K, V])
 		if kvNode.hash != hash {
-			return *new(T), ErrorNotFound
+			return *new(V), ErrorNotFound
 		}
 
 		for iter := kvNode.kvList; iter != nil; iter = iter.next {
-			if string(iter.key) == string(key) {
+			if equal(iter.key, key) {
 				return iter.value, nil
 			}
 		}
 	} else {
-		return entry.(*BitmapNode[T]).find(depth+1, hash, key)
+		return entry.(*mapBitmapNode[K, V]).find(depth+1, hash, key, equal)
 	}
-	return *new(T), ErrorNotFound
+	return *new(V), ErrorNotFound
 }
 
-func (h *BitmapNode[T]) traversal(visitor visitor.KvVisitor[Key, T]) {
+// traversal returns false if the visitor stops the traversal
+func (h *mapBitmapNode[K, V]) traversal(visitor visitor.KvVisitor[K, V]) bool {
 	for _, entry := range h.children {
 		if entry.Type() == BITMAP_NODE {
-			entry.(*BitmapNode[T]).traversal(visitor)
+			if !entry.(*mapBitmapNode[K, V]).traversal(visitor) {
+				return false
+			}
 		} else {
-			node := entry.(*KvNode[T])
+			node := entry.(*mapKvNode[K, V])
 			for kv := node.kvList; kv != nil; kv = kv.next {
 				if !visitor(kv.key, kv.value) {
-					return
+					return false
 				}
 			}
 		}
 	}
+	return true
 }
 
-func (h *BitmapNode[T]) erase(depth int, hash uint64, key Key) bool {
+func (h *mapBitmapNode[K, V]) erase(depth int, hash uint64, key K, equal Equaler[K]) bool {
 	pos := pos(hash, depth) //hash in current node's position
 	bitPos := bitPos(pos)   //hash in current bitmap's position in bit
 	if bitPos&h.bitmap == 0 {
@@ -185,15 +162,15 @@ func (h *BitmapNode[T]) erase(depth int, hash uint64, key Key) bool {
 	index := h.Index(bitPos)
 	entry := h.children[index]
 	if entry.Type() == KV_NODE {
-		kvNode := entry.(*KvNode[T])
+		kvNode := entry.(*mapKvNode[K, V])
 		if kvNode.hash != hash {
 			return false
 		}
 		iter := kvNode.kvList
-		var preIter *KvPair[T]
+		var preIter *mapKvPair[K, V]
 		found := false
 		for ; iter != nil; iter = iter.next {
-			if string(iter.key) == string(key) {
+			if equal(iter.key, key) {
 				found = true
 				break
 			}
@@ -223,105 +200,87 @@ func (h *BitmapNode[T]) erase(depth int, hash uint64, key Key) bool {
 		return false
 	}
 
-	bitmapNode := entry.(*BitmapNode[T])
-	ok := bitmapNode.erase(depth+1, hash, key)
+	bitmapNode := entry.(*mapBitmapNode[K, V])
+	ok := bitmapNode.erase(depth+1, hash, key, equal)
 	// change bitmapNode to kvNode, if a bitmapNode has only one kvNode
 	if ok && len(bitmapNode.children) == 1 && bitmapNode.children[0].Type() == KV_NODE {
-		child := bitmapNode.children[0].(*KvNode[T])
+		child := bitmapNode.children[0].(*mapKvNode[K, V])
 		h.children[index] = child
 	}
 	return ok
 }
 
 // Type returns the node type
-func (h *KvNode[T]) Type() int {
+func (h *mapKvNode[K, V]) Type() int {
 	return KV_NODE
 }
 
 // BitPosNum returns the bit position
-func (h *KvNode[T]) BitPosNum(depth int) uint64 {
+func (h *mapKvNode[K, V]) BitPosNum(depth int) uint64 {
 	return uint64(1) << pos(h.hash, depth)
 }
 
+func (h *mapKvNode[K, V]) pos(depth int) uint8 {
+	return pos(h.hash, depth)
+}
+
+// Hamt is an implementation of hash-array-mapped-trie with []byte keys, it is a thin wrapper of Map[Key, T]
+type Hamt[T any] struct {
+	m *Map[Key, T]
+}
+
 // New creates a Hamt(hash array mapped trie) instance
 func New[T any](opts ...Option) *Hamt[T] {
-	option := Options{
-		locker: defaultLocker,
-	}
-	for _, opt := range opts {
-		opt(&option)
-	}
-	return &Hamt[T]{locker: option.locker}
+	return &Hamt[T]{m: NewMap[Key, T](BytesHasher[Key], BytesEqual[Key], opts...)}
 }
 
 // Insert inserts a key-value pair into the hamt
 func (h *Hamt[T]) Insert(key Key, value T) {
-	keyHash := hash(key)
-
-	h.locker.Lock()
-	defer h.locker.Unlock()
-
-	h.root.insert(0, keyHash, &KvPair[T]{key: key, value: value})
+	h.m.Insert(key, value)
 }
 
 // Get returns the value by the passed key if the key is in the hamt, otherwise returns nil
 func (h *Hamt[T]) Get(key Key) (T, error) {
-	keyHash := hash(key)
-
-	h.locker.RLock()
-	defer h.locker.RUnlock()
-
-	return h.root.find(0, keyHash, key)
+	return h.m.Get(key)
 }
 
 // Erase erases the key-value pair in hamt, and returns true if succeed.
 func (h *Hamt[T]) Erase(key Key) bool {
-	keyHash := hash(key)
-
-	h.locker.Lock()
-	defer h.locker.Unlock()
-
-	return h.root.erase(0, keyHash, key)
+	return h.m.Erase(key)
 }
 
 // Keys returns keys in Hamt
 func (h *Hamt[T]) Keys() []Key {
-	h.locker.RLock()
-	defer h.locker.RUnlock()
-
-	keys := make([]Key, 0)
-	h.root.traversal(func(key Key, _ T) bool {
-		keys = append(keys, key)
-		return true
-	})
-	return keys
+	return h.m.Keys()
 }
 
 // StringKeys returns keys in Hamt
 func (h *Hamt[T]) StringKeys() []string {
-	h.locker.RLock()
-	defer h.locker.RUnlock()
-
 	keys := make([]string, 0)
-	h.root.traversal(func(key Key, value T) bool {
+	h.m.Traversal(func(key Key, value T) bool {
 		keys = append(keys, string(key))
 		return true
 	})
 	return keys
 }
 
+// Begin returns an iterator with the first key-value pair in Hamt
+func (h *Hamt[T]) Begin() *HamtIterator[Key, T] {
+	return h.m.Begin()
+}
+
+// Seek returns an iterator with the first key-value pair at or after the position of the passed cursor in Hamt
+func (h *Hamt[T]) Seek(cursor Cursor) *HamtIterator[Key, T] {
+	return h.m.Seek(cursor)
+}
+
 // Traversal traversals elements in Hamt, it will not stop until to the end or the visitor returns false
 func (h *Hamt[T]) Traversal(visitor visitor.KvVisitor[Key, T]) {
-	h.locker.RLock()
-	defer h.locker.RUnlock()
-
-	h.root.traversal(visitor)
+	h.m.Traversal(visitor)
 }
 
 func hash(a []byte) uint64 {
-	h := fnv.New64()
-	h.Write(a)
-	return h.Sum64()
+	return BytesHasher(a)
 }
 
 func pos(hash uint64, depth int) uint8 {
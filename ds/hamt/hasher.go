@@ -0,0 +1,115 @@
+package hamt
+
+import (
+	"math"
+	"reflect"
+
+	"github.com/liyue201/gostl/utils/comparator"
+)
+
+// FNV-1a constants
+const (
+	fnvOffset64 = 14695981039346656037
+	fnvPrime64  = 1099511628211
+)
+
+// Hasher is a function used to calculate the hash value of a key
+type Hasher[K any] func(key K) uint64
+
+// Equaler is a function used to check whether two keys are equal
+type Equaler[K any] func(a, b K) bool
+
+// BytesHasher returns the FNV-1 hash value of a byte slice key
+func BytesHasher[K ~[]byte](key K) uint64 {
+	h := uint64(fnvOffset64)
+	for _, c := range key {
+		h *= fnvPrime64
+		h ^= uint64(c)
+	}
+	return h
+}
+
+// BytesEqual returns true if the two byte slice keys have the same content
+func BytesEqual[K ~[]byte](a, b K) bool {
+	return string(a) == string(b)
+}
+
+// StringHasher returns the FNV-1a hash value of a string key
+func StringHasher[K ~string](key K) uint64 {
+	h := uint64(fnvOffset64)
+	for i := 0; i < len(key); i++ {
+		h ^= uint64(key[i])
+		h *= fnvPrime64
+	}
+	return h
+}
+
+// OrderedHasher returns the hash value of a key with comparator.Ordered type
+func OrderedHasher[K comparator.Ordered](key K) uint64 {
+	switch k := any(key).(type) {
+	case string:
+		return StringHasher(k)
+	case int:
+		return mix64(uint64(k))
+	case int8:
+		return mix64(uint64(k))
+	case int16:
+		return mix64(uint64(k))
+	case int32:
+		return mix64(uint64(k))
+	case int64:
+		return mix64(uint64(k))
+	case uint:
+		return mix64(uint64(k))
+	case uint8:
+		return mix64(uint64(k))
+	case uint16:
+		return mix64(uint64(k))
+	case uint32:
+		return mix64(uint64(k))
+	case uint64:
+		return mix64(k)
+	case uintptr:
+		return mix64(uint64(k))
+	case float32:
+		return floatHash(float64(k))
+	case float64:
+		return floatHash(k)
+	}
+
+	// types defined with an ordered underlying type
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return StringHasher(v.String())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return mix64(uint64(v.Int()))
+	case reflect.Float32, reflect.Float64:
+		return floatHash(v.Float())
+	default:
+		return mix64(v.Uint())
+	}
+}
+
+// OrderedEqual returns true if a is equal to b
+func OrderedEqual[K comparable](a, b K) bool {
+	return a == b
+}
+
+func floatHash(f float64) uint64 {
+	if f == 0 {
+		// +0 and -0 are equal, so they must have the same hash value
+		return mix64(0)
+	}
+	return mix64(math.Float64bits(f))
+}
+
+// mix64 is the finalizer of splitmix64, it spreads the bits of x over the whole hash value
+func mix64(x uint64) uint64 {
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
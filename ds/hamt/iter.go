@@ -0,0 +1,61 @@
+//go:build go1.23
+
+package hamt
+
+import "iter"
+
+// All returns an iterator over key-value pairs in the Hamt, the order is determined by the key hashes
+func (h *Hamt[T]) All() iter.Seq2[Key, T] {
+	return h.m.All()
+}
+
+// IterKeys returns an iterator over keys in the Hamt, the order is determined by the key hashes
+func (h *Hamt[T]) IterKeys() iter.Seq[Key] {
+	return h.m.IterKeys()
+}
+
+// Values returns an iterator over values in the Hamt, the order is determined by the key hashes
+func (h *Hamt[T]) Values() iter.Seq[T] {
+	return h.m.Values()
+}
+
+// All returns an iterator over key-value pairs in the Map, the order is determined by the key hashes
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		m.root.traversal(yield)
+	}
+}
+
+// IterKeys returns an iterator over keys in the Map, the order is determined by the key hashes
+func (m *Map[K, V]) IterKeys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		m.root.traversal(func(key K, _ V) bool {
+			return yield(key)
+		})
+	}
+}
+
+// Values returns an iterator over values in the Map, the order is determined by the key hashes
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		m.root.traversal(func(_ K, value V) bool {
+			return yield(value)
+		})
+	}
+}
+
+// All returns an iterator over key-value pairs in the PersistentHamt, the order is determined by the key hashes
+func (h *PersistentHamt[T]) All() iter.Seq2[Key, T] {
+	return func(yield func(Key, T) bool) {
+		h.root.traversal(yield)
+	}
+}
@@ -0,0 +1,41 @@
+//go:build go1.23
+
+package hamt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHamtIterSeq(t *testing.T) {
+	h := New[int](WithGoroutineSafe())
+	for i := 0; i < 1000; i++ {
+		h.Insert(Key(fmt.Sprintf("%d", i)), i)
+	}
+
+	m := make(map[string]int)
+	for k, v := range h.All() {
+		m[string(k)] = v
+	}
+	assert.Equal(t, 1000, len(m))
+	for k, v := range m {
+		assert.Equal(t, fmt.Sprintf("%d", v), k)
+	}
+
+	count := 0
+	for range h.IterKeys() {
+		count++
+		if count == 100 {
+			break
+		}
+	}
+	assert.Equal(t, 100, count)
+
+	sum := 0
+	for v := range h.Values() {
+		sum += v
+	}
+	assert.Equal(t, 999*1000/2, sum)
+}
@@ -0,0 +1,200 @@
+package hamt
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+const maxDepth = (64 + Fanout - 1) / Fanout
+
+var ErrorInvalidCursor = errors.New("invalid cursor")
+
+// Cursor records the position of a HamtIterator: the hash of the key and the index of the key in its collision list.
+// It can be serialized into a token, and used to resume a scan with Seek even if the Hamt has been modified
+type Cursor struct {
+	Hash  uint64
+	Index int
+}
+
+// MarshalText encodes the cursor into a text token
+func (c Cursor) MarshalText() ([]byte, error) {
+	return []byte(fmt.Sprintf("%016x.%d", c.Hash, c.Index)), nil
+}
+
+// UnmarshalText decodes the cursor from a text token returned by MarshalText
+func (c *Cursor) UnmarshalText(text []byte) error {
+	var hash uint64
+	var index int
+	n, err := fmt.Sscanf(string(text), "%016x.%d", &hash, &index)
+	if err != nil || n != 2 || index < 0 {
+		return ErrorInvalidCursor
+	}
+	c.Hash = hash
+	c.Index = index
+	return nil
+}
+
+// String returns the text token of the cursor
+func (c Cursor) String() string {
+	text, _ := c.MarshalText()
+	return string(text)
+}
+
+// iterFrame is a bitmap node on the path from the root to the current key-value pair
+type iterFrame[K, V any] struct {
+	node  *mapBitmapNode[K, V]
+	index int // index of the visiting child
+}
+
+// HamtIterator is an iterator implementation of Hamt, it visits the key-value pairs in the order of
+// the bitmap nodes' children. Note that the iterator becomes invalid after the Hamt is modified,
+// in that case, save its Cursor and Seek it again
+type HamtIterator[K, V any] struct {
+	stack []iterFrame[K, V]
+	hash  uint64
+	kv    *mapKvPair[K, V]
+	index int // index of kv in the collision list
+}
+
+var _ iterator.ConstKvIterator[Key, int] = (*HamtIterator[Key, int])(nil)
+
+func newIterator[K, V any](root *mapBitmapNode[K, V]) *HamtIterator[K, V] {
+	iter := &HamtIterator[K, V]{stack: make([]iterFrame[K, V], 0, maxDepth)}
+	iter.stack = append(iter.stack, iterFrame[K, V]{node: root})
+	iter.settle()
+	return iter
+}
+
+func seekIterator[K, V any](root *mapBitmapNode[K, V], cursor Cursor) *HamtIterator[K, V] {
+	iter := &HamtIterator[K, V]{stack: make([]iterFrame[K, V], 0, maxDepth)}
+	iter.stack = append(iter.stack, iterFrame[K, V]{node: root})
+	for depth := 0; ; depth++ {
+		frame := &iter.stack[len(iter.stack)-1]
+		pos := pos(cursor.Hash, depth)
+		bitPos := bitPos(pos)
+		frame.index = frame.node.Index(bitPos)
+		if bitPos&frame.node.bitmap == 0 {
+			// the first child after pos
+			break
+		}
+		entry := frame.node.children[frame.index]
+		if entry.Type() == BITMAP_NODE {
+			iter.stack = append(iter.stack, iterFrame[K, V]{node: entry.(*mapBitmapNode[K, V])})
+			continue
+		}
+		kvNode := entry.(*mapKvNode[K, V])
+		if kvNode.hash == cursor.Hash {
+			index := 0
+			for kv := kvNode.kvList; kv != nil; kv = kv.next {
+				if index == cursor.Index {
+					iter.hash = kvNode.hash
+					iter.kv = kv
+					iter.index = index
+					return iter
+				}
+				index++
+			}
+			frame.index++
+		} else if hashLess(kvNode.hash, cursor.Hash) {
+			frame.index++
+		}
+		break
+	}
+	iter.settle()
+	return iter
+}
+
+// settle moves the iterator to the first key-value pair from the visiting child of the top frame
+func (iter *HamtIterator[K, V]) settle() {
+	iter.kv = nil
+	for len(iter.stack) > 0 {
+		frame := &iter.stack[len(iter.stack)-1]
+		if frame.index >= len(frame.node.children) {
+			iter.stack = iter.stack[:len(iter.stack)-1]
+			if len(iter.stack) > 0 {
+				iter.stack[len(iter.stack)-1].index++
+			}
+			continue
+		}
+		entry := frame.node.children[frame.index]
+		if entry.Type() == BITMAP_NODE {
+			iter.stack = append(iter.stack, iterFrame[K, V]{node: entry.(*mapBitmapNode[K, V])})
+			continue
+		}
+		kvNode := entry.(*mapKvNode[K, V])
+		iter.hash = kvNode.hash
+		iter.kv = kvNode.kvList
+		iter.index = 0
+		return
+	}
+}
+
+// IsValid returns true if the iterator is valid, otherwise returns false
+func (iter *HamtIterator[K, V]) IsValid() bool {
+	return iter.kv != nil
+}
+
+// Next moves the iterator to the next key-value pair, and returns itself
+func (iter *HamtIterator[K, V]) Next() iterator.ConstIterator[V] {
+	if !iter.IsValid() {
+		return iter
+	}
+	if iter.kv.next != nil {
+		iter.kv = iter.kv.next
+		iter.index++
+		return iter
+	}
+	iter.stack[len(iter.stack)-1].index++
+	iter.settle()
+	return iter
+}
+
+// Key returns the key of the key-value pair the iterator point to
+func (iter *HamtIterator[K, V]) Key() K {
+	return iter.kv.key
+}
+
+// Value returns the value of the key-value pair the iterator point to
+func (iter *HamtIterator[K, V]) Value() V {
+	return iter.kv.value
+}
+
+// Cursor returns the cursor of the key-value pair the iterator point to
+func (iter *HamtIterator[K, V]) Cursor() Cursor {
+	return Cursor{Hash: iter.hash, Index: iter.index}
+}
+
+// Clone clones the iterator into a new HamtIterator
+func (iter *HamtIterator[K, V]) Clone() iterator.ConstIterator[V] {
+	other := &HamtIterator[K, V]{
+		stack: make([]iterFrame[K, V], len(iter.stack), maxDepth),
+		hash:  iter.hash,
+		kv:    iter.kv,
+		index: iter.index,
+	}
+	copy(other.stack, iter.stack)
+	return other
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *HamtIterator[K, V]) Equal(other iterator.ConstIterator[V]) bool {
+	otherIter, ok := other.(*HamtIterator[K, V])
+	if !ok {
+		return false
+	}
+	return otherIter.kv == iter.kv
+}
+
+// hashLess returns true if hash a is visited before hash b, which means
+// a is less than b when comparing them Fanout bits by Fanout bits from the lowest bits
+func hashLess(a, b uint64) bool {
+	for depth := 0; depth < maxDepth; depth++ {
+		posA, posB := pos(a, depth), pos(b, depth)
+		if posA != posB {
+			return posA < posB
+		}
+	}
+	return false
+}
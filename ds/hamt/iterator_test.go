@@ -0,0 +1,135 @@
+package hamt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHamtIterator(t *testing.T) {
+	h := New[int]()
+	assert.False(t, h.Begin().IsValid())
+
+	for i := 0; i < 1000; i++ {
+		h.Insert(Key(fmt.Sprintf("%d", i)), i)
+	}
+
+	keys := h.StringKeys()
+	i := 0
+	for iter := h.Begin(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, keys[i], string(iter.Key()))
+		assert.Equal(t, keys[i], fmt.Sprintf("%d", iter.Value()))
+		i++
+	}
+	assert.Equal(t, 1000, i)
+
+	iter := h.Begin()
+	iter.Next()
+	clone := iter.Clone().(*HamtIterator[Key, int])
+	assert.True(t, clone.Equal(iter))
+	clone.Next()
+	assert.False(t, clone.Equal(iter))
+	assert.Equal(t, keys[1], string(iter.Key()))
+	assert.Equal(t, keys[2], string(clone.Key()))
+
+	// seek every position
+	i = 0
+	for iter := h.Begin(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, keys[i], string(h.Seek(iter.Cursor()).Key()))
+		i++
+	}
+	assert.Equal(t, keys[0], string(h.Seek(Cursor{}).Key()))
+}
+
+func TestHamtIteratorPaging(t *testing.T) {
+	h := New[int]()
+	for i := 0; i < 1000; i++ {
+		h.Insert(Key(fmt.Sprintf("%d", i)), i)
+	}
+
+	visited := make(map[int]int)
+	token := ""
+	for page := 0; ; page++ {
+		var iter *HamtIterator[Key, int]
+		if token == "" {
+			iter = h.Begin()
+		} else {
+			var cursor Cursor
+			assert.Nil(t, cursor.UnmarshalText([]byte(token)))
+			iter = h.Seek(cursor)
+		}
+		for n := 0; n < 100 && iter.IsValid(); n++ {
+			visited[iter.Value()]++
+			iter.Next()
+		}
+		if !iter.IsValid() {
+			break
+		}
+		token = iter.Cursor().String()
+
+		// concurrent mutations between two pages
+		h.Erase(Key(fmt.Sprintf("%d", iter.Value())))
+		h.Insert(Key(fmt.Sprintf("%d", 1000+page)), 1000+page)
+	}
+
+	for i := 0; i < 1000; i++ {
+		assert.True(t, visited[i] <= 1)
+	}
+	assert.True(t, len(visited) >= 990)
+}
+
+func TestHamtIteratorCollision(t *testing.T) {
+	m := NewMap[int, int](func(key int) uint64 { return uint64(key % 10) }, OrderedEqual[int])
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+
+	count := 0
+	var cursor Cursor
+	for iter := m.Begin(); iter.IsValid(); iter.Next() {
+		if count == 55 {
+			cursor = iter.Cursor()
+		}
+		count++
+	}
+	assert.Equal(t, 100, count)
+	assert.Equal(t, uint64(5), cursor.Hash)
+	assert.Equal(t, 5, cursor.Index)
+
+	count = 0
+	for iter := m.Seek(cursor); iter.IsValid(); iter.Next() {
+		count++
+	}
+	assert.Equal(t, 45, count)
+
+	iter := m.Seek(Cursor{Hash: 5, Index: 10})
+	assert.Equal(t, uint64(6), iter.Cursor().Hash)
+	assert.Equal(t, 0, iter.Cursor().Index)
+}
+
+func TestCursorText(t *testing.T) {
+	c := Cursor{Hash: 0xdeadbeef, Index: 3}
+	var c2 Cursor
+	assert.Nil(t, c2.UnmarshalText([]byte(c.String())))
+	assert.Equal(t, c, c2)
+	assert.Equal(t, ErrorInvalidCursor, c2.UnmarshalText([]byte("bad")))
+	assert.True(t, hashLess(1<<6, 2))
+	assert.False(t, hashLess(2, 2))
+}
+
+func TestPersistentHamtIterator(t *testing.T) {
+	h := NewPersistent[int]()
+	for i := 0; i < 100; i++ {
+		h = h.Insert(Key(fmt.Sprintf("%d", i)), i)
+	}
+	count := 0
+	for iter := h.Begin(); iter.IsValid(); iter.Next() {
+		count++
+	}
+	assert.Equal(t, 100, count)
+
+	iter := h.Begin()
+	iter.Next()
+	assert.True(t, h.Seek(iter.Cursor()).Equal(iter))
+}
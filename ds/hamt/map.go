@@ -0,0 +1,118 @@
+package hamt
+
+import (
+	gosync "sync"
+
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds Hamt's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function type used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe is used to config a Hamt with goroutine-safe
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// Map is an implementation of hash-array-mapped-trie with generic keys,
+// the keys are hashed by the passed Hasher and compared by the passed Equaler
+type Map[K, V any] struct {
+	root   mapBitmapNode[K, V]
+	hasher Hasher[K]
+	equal  Equaler[K]
+	locker sync.Locker
+}
+
+// NewMap creates a Map instance with the passed hasher and equal function
+func NewMap[K, V any](hasher Hasher[K], equal Equaler[K], opts ...Option) *Map[K, V] {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &Map[K, V]{
+		hasher: hasher,
+		equal:  equal,
+		locker: option.locker,
+	}
+}
+
+// Insert inserts a key-value pair into the Map
+func (m *Map[K, V]) Insert(key K, value V) {
+	keyHash := m.hasher(key)
+
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.root.insert(0, keyHash, &mapKvPair[K, V]{key: key, value: value}, m.equal)
+}
+
+// Get returns the value by the passed key if the key is in the Map, otherwise returns error
+func (m *Map[K, V]) Get(key K) (V, error) {
+	keyHash := m.hasher(key)
+
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.root.find(0, keyHash, key, m.equal)
+}
+
+// Erase erases the key-value pair in the Map, and returns true if succeed.
+func (m *Map[K, V]) Erase(key K) bool {
+	keyHash := m.hasher(key)
+
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	return m.root.erase(0, keyHash, key, m.equal)
+}
+
+// Keys returns keys in the Map
+func (m *Map[K, V]) Keys() []K {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	keys := make([]K, 0)
+	m.root.traversal(func(key K, _ V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// Begin returns an iterator with the first key-value pair in the Map
+func (m *Map[K, V]) Begin() *HamtIterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return newIterator(&m.root)
+}
+
+// Seek returns an iterator with the first key-value pair at or after the position of the passed cursor in the Map
+func (m *Map[K, V]) Seek(cursor Cursor) *HamtIterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return seekIterator(&m.root, cursor)
+}
+
+// Traversal traversals elements in the Map, it will not stop until to the end or the visitor returns false
+func (m *Map[K, V]) Traversal(visitor visitor.KvVisitor[K, V]) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	m.root.traversal(visitor)
+}
@@ -0,0 +1,78 @@
+package hamt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type point struct {
+	x, y int
+}
+
+type myString string
+
+func TestMap(t *testing.T) {
+	m := NewMap[int, int](OrderedHasher[int], OrderedEqual[int], WithGoroutineSafe())
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, i*10)
+	}
+	for i := 0; i < 1000; i++ {
+		v, err := m.Get(i)
+		assert.Nil(t, err)
+		assert.Equal(t, i*10, v)
+	}
+	assert.Equal(t, 1000, len(m.Keys()))
+
+	for i := 0; i < 1000; i += 2 {
+		assert.True(t, m.Erase(i))
+	}
+	assert.False(t, m.Erase(0))
+	_, err := m.Get(0)
+	assert.Equal(t, ErrorNotFound, err)
+
+	count := 0
+	m.Traversal(func(key, value int) bool {
+		assert.Equal(t, 1, key%2)
+		assert.Equal(t, key*10, value)
+		count++
+		return true
+	})
+	assert.Equal(t, 500, count)
+}
+
+func TestMapCustomHasher(t *testing.T) {
+	// a poor hasher makes every key collide
+	m := NewMap[point, string](func(p point) uint64 { return uint64(p.x) }, OrderedEqual[point])
+	m.Insert(point{1, 2}, "a")
+	m.Insert(point{1, 3}, "b")
+	m.Insert(point{2, 3}, "c")
+	m.Insert(point{1, 2}, "d")
+
+	v, _ := m.Get(point{1, 2})
+	assert.Equal(t, "d", v)
+	v, _ = m.Get(point{1, 3})
+	assert.Equal(t, "b", v)
+	assert.True(t, m.Erase(point{1, 2}))
+	_, err := m.Get(point{1, 2})
+	assert.Equal(t, ErrorNotFound, err)
+	assert.Equal(t, 2, len(m.Keys()))
+}
+
+func TestHashers(t *testing.T) {
+	assert.Equal(t, OrderedHasher("abc"), StringHasher("abc"))
+	assert.Equal(t, OrderedHasher(myString("abc")), StringHasher("abc"))
+	assert.Equal(t, OrderedHasher(0.0), OrderedHasher(math.Copysign(0, -1)))
+	assert.Equal(t, OrderedHasher(int64(5)), OrderedHasher(5))
+	assert.NotEqual(t, OrderedHasher(5), OrderedHasher(6))
+	assert.NotEqual(t, OrderedHasher(1.5), OrderedHasher(2.5))
+	assert.Equal(t, hash([]byte("abc")), BytesHasher(Key("abc")))
+	assert.True(t, BytesEqual(Key("abc"), Key("abc")))
+	assert.False(t, BytesEqual(Key("abc"), Key("abd")))
+
+	m := NewMap[myString, int](OrderedHasher[myString], OrderedEqual[myString])
+	m.Insert("x", 1)
+	v, _ := m.Get("x")
+	assert.Equal(t, 1, v)
+}
@@ -0,0 +1,297 @@
+package hamt
+
+import (
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// editToken marks the nodes owned by a TransientHamt, these nodes can be modified in place
+type editToken struct {
+	_ byte
+}
+
+// PersistentHamt is an immutable Hamt. Insert and Erase return a new PersistentHamt which shares
+// all unchanged nodes with the old one, so a PersistentHamt can be read by multi goroutines without any lock
+type PersistentHamt[T any] struct {
+	root *mapBitmapNode[Key, T]
+	size int
+}
+
+// TransientHamt is a mutable builder of PersistentHamt, it modifies the nodes it owns in place,
+// so it is much faster than PersistentHamt for batch loading.
+// Note that a TransientHamt is not goroutine-safe, but the PersistentHamts returned by its Snapshot are
+type TransientHamt[T any] struct {
+	root *mapBitmapNode[Key, T]
+	size int
+	edit *editToken
+}
+
+// NewPersistent creates an empty PersistentHamt
+func NewPersistent[T any]() *PersistentHamt[T] {
+	return &PersistentHamt[T]{root: &mapBitmapNode[Key, T]{}}
+}
+
+// Insert returns a new PersistentHamt with the key-value pair inserted, the PersistentHamt h is not changed
+func (h *PersistentHamt[T]) Insert(key Key, value T) *PersistentHamt[T] {
+	root, added := h.root.assoc(nil, 0, hash(key), key, value, BytesEqual[Key])
+	size := h.size
+	if added {
+		size++
+	}
+	return &PersistentHamt[T]{root: root, size: size}
+}
+
+// Erase returns a new PersistentHamt without the passed key and true if the key is in the PersistentHamt h,
+// otherwise returns h itself and false. The PersistentHamt h is not changed
+func (h *PersistentHamt[T]) Erase(key Key) (*PersistentHamt[T], bool) {
+	root, removed := h.root.dissoc(nil, 0, hash(key), key, BytesEqual[Key])
+	if !removed {
+		return h, false
+	}
+	return &PersistentHamt[T]{root: root, size: h.size - 1}, true
+}
+
+// Get returns the value by the passed key if the key is in the PersistentHamt, otherwise returns error
+func (h *PersistentHamt[T]) Get(key Key) (T, error) {
+	return h.root.find(0, hash(key), key, BytesEqual[Key])
+}
+
+// Len returns the amount of key-value pairs in the PersistentHamt
+func (h *PersistentHamt[T]) Len() int {
+	return h.size
+}
+
+// Keys returns keys in the PersistentHamt
+func (h *PersistentHamt[T]) Keys() []Key {
+	keys := make([]Key, 0, h.size)
+	h.root.traversal(func(key Key, _ T) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+// StringKeys returns keys in the PersistentHamt
+func (h *PersistentHamt[T]) StringKeys() []string {
+	keys := make([]string, 0, h.size)
+	h.root.traversal(func(key Key, _ T) bool {
+		keys = append(keys, string(key))
+		return true
+	})
+	return keys
+}
+
+// Traversal traversals elements in the PersistentHamt, it will not stop until to the end or the visitor returns false
+func (h *PersistentHamt[T]) Traversal(visitor visitor.KvVisitor[Key, T]) {
+	h.root.traversal(visitor)
+}
+
+// Begin returns an iterator with the first key-value pair in the PersistentHamt
+func (h *PersistentHamt[T]) Begin() *HamtIterator[Key, T] {
+	return newIterator(h.root)
+}
+
+// Seek returns an iterator with the first key-value pair at or after the position of the passed cursor in the PersistentHamt
+func (h *PersistentHamt[T]) Seek(cursor Cursor) *HamtIterator[Key, T] {
+	return seekIterator(h.root, cursor)
+}
+
+// Transient returns a TransientHamt with the same content as the PersistentHamt, it takes O(1) time
+func (h *PersistentHamt[T]) Transient() *TransientHamt[T] {
+	return &TransientHamt[T]{root: h.root, size: h.size, edit: &editToken{}}
+}
+
+// Insert inserts a key-value pair into the TransientHamt
+func (h *TransientHamt[T]) Insert(key Key, value T) {
+	root, added := h.root.assoc(h.edit, 0, hash(key), key, value, BytesEqual[Key])
+	h.root = root
+	if added {
+		h.size++
+	}
+}
+
+// Erase erases the key-value pair in the TransientHamt, and returns true if succeed
+func (h *TransientHamt[T]) Erase(key Key) bool {
+	root, removed := h.root.dissoc(h.edit, 0, hash(key), key, BytesEqual[Key])
+	if removed {
+		h.root = root
+		h.size--
+	}
+	return removed
+}
+
+// Get returns the value by the passed key if the key is in the TransientHamt, otherwise returns error
+func (h *TransientHamt[T]) Get(key Key) (T, error) {
+	return h.root.find(0, hash(key), key, BytesEqual[Key])
+}
+
+// Len returns the amount of key-value pairs in the TransientHamt
+func (h *TransientHamt[T]) Len() int {
+	return h.size
+}
+
+// Traversal traversals elements in the TransientHamt, it will not stop until to the end or the visitor returns false
+func (h *TransientHamt[T]) Traversal(visitor visitor.KvVisitor[Key, T]) {
+	h.root.traversal(visitor)
+}
+
+// Snapshot returns a PersistentHamt with the current content of the TransientHamt, it takes O(1) time.
+// The TransientHamt can still be used after that, the nodes shared with the snapshot will be copied before modified
+func (h *TransientHamt[T]) Snapshot() *PersistentHamt[T] {
+	h.edit = &editToken{}
+	return &PersistentHamt[T]{root: h.root, size: h.size}
+}
+
+// editable returns h itself if it is owned by edit, otherwise returns a copy of h owned by edit
+func (h *mapBitmapNode[K, V]) editable(edit *editToken) *mapBitmapNode[K, V] {
+	if edit != nil && h.edit == edit {
+		return h
+	}
+	children := make([]Entry, len(h.children), len(h.children)+1)
+	copy(children, h.children)
+	return &mapBitmapNode[K, V]{
+		bitmap:   h.bitmap,
+		children: children,
+		pos:      h.pos,
+		edit:     edit,
+	}
+}
+
+// assoc returns a node with the key-value pair inserted and true if the key is newly added
+func (h *mapBitmapNode[K, V]) assoc(edit *editToken, depth int, hash uint64, key K, value V, equal Equaler[K]) (*mapBitmapNode[K, V], bool) {
+	pos := pos(hash, depth) //hash in current node's position
+	bitPos := bitPos(pos)   //hash in current bitmap's position in bit
+	index := h.Index(bitPos)
+	if bitPos&h.bitmap == 0 {
+		n := h.editable(edit)
+		n.bitmap |= bitPos
+		n.children = append(n.children, nil)
+		copy(n.children[index+1:], n.children[index:])
+		n.children[index] = &mapKvNode[K, V]{
+			hash:   hash,
+			kvList: &mapKvPair[K, V]{key: key, value: value},
+		}
+		return n, true
+	}
+
+	var child Entry
+	added := false
+	entry := h.children[index]
+	if entry.Type() == KV_NODE {
+		kvNode := entry.(*mapKvNode[K, V])
+		if kvNode.hash == hash {
+			var kvList *mapKvPair[K, V]
+			kvList, added = assocKvList(kvNode.kvList, key, value, equal)
+			child = &mapKvNode[K, V]{hash: hash, kvList: kvList}
+		} else {
+			newKvNode := &mapKvNode[K, V]{
+				hash:   hash,
+				kvList: &mapKvPair[K, V]{key: key, value: value},
+			}
+			child = mergeKvNodes(edit, depth+1, pos, kvNode, newKvNode)
+			added = true
+		}
+	} else {
+		child, added = entry.(*mapBitmapNode[K, V]).assoc(edit, depth+1, hash, key, value, equal)
+	}
+	n := h.editable(edit)
+	n.children[index] = child
+	return n, added
+}
+
+// dissoc returns a node without the passed key and true if the key is removed
+func (h *mapBitmapNode[K, V]) dissoc(edit *editToken, depth int, hash uint64, key K, equal Equaler[K]) (*mapBitmapNode[K, V], bool) {
+	pos := pos(hash, depth) //hash in current node's position
+	bitPos := bitPos(pos)   //hash in current bitmap's position in bit
+	if bitPos&h.bitmap == 0 {
+		return h, false
+	}
+	index := h.Index(bitPos)
+	entry := h.children[index]
+	if entry.Type() == KV_NODE {
+		kvNode := entry.(*mapKvNode[K, V])
+		if kvNode.hash != hash {
+			return h, false
+		}
+		kvList, removed := dissocKvList(kvNode.kvList, key, equal)
+		if !removed {
+			return h, false
+		}
+		n := h.editable(edit)
+		if kvList == nil {
+			n.bitmap &= ^bitPos
+			n.children = append(n.children[:index], n.children[index+1:]...)
+		} else {
+			n.children[index] = &mapKvNode[K, V]{hash: hash, kvList: kvList}
+		}
+		return n, true
+	}
+
+	child, removed := entry.(*mapBitmapNode[K, V]).dissoc(edit, depth+1, hash, key, equal)
+	if !removed {
+		return h, false
+	}
+	n := h.editable(edit)
+	// change bitmapNode to kvNode, if a bitmapNode has only one kvNode
+	if len(child.children) == 1 && child.children[0].Type() == KV_NODE {
+		n.children[index] = child.children[0]
+	} else {
+		n.children[index] = child
+	}
+	return n, true
+}
+
+// mergeKvNodes returns a new bitmap node at position pos holding two kv nodes with different hashes
+func mergeKvNodes[K, V any](edit *editToken, depth int, pos uint8, a, b *mapKvNode[K, V]) *mapBitmapNode[K, V] {
+	n := &mapBitmapNode[K, V]{pos: pos, edit: edit}
+	posA := a.pos(depth)
+	posB := b.pos(depth)
+	if posA == posB {
+		n.bitmap = bitPos(posA)
+		n.children = []Entry{mergeKvNodes(edit, depth+1, posA, a, b)}
+		return n
+	}
+	n.bitmap = bitPos(posA) | bitPos(posB)
+	if posA < posB {
+		n.children = []Entry{a, b}
+	} else {
+		n.children = []Entry{b, a}
+	}
+	return n
+}
+
+// assocKvList returns a copy of list with the key-value pair inserted and true if the key is newly added,
+// the nodes after the updated one are shared with the old list
+func assocKvList[K, V any](list *mapKvPair[K, V], key K, value V, equal Equaler[K]) (*mapKvPair[K, V], bool) {
+	for iter := list; iter != nil; iter = iter.next {
+		if equal(iter.key, key) {
+			return copyKvListUntil(list, iter, &mapKvPair[K, V]{key: key, value: value, next: iter.next}), false
+		}
+	}
+	return &mapKvPair[K, V]{key: key, value: value, next: list}, true
+}
+
+// dissocKvList returns a copy of list without the passed key and true if the key is removed,
+// the nodes after the removed one are shared with the old list
+func dissocKvList[K, V any](list *mapKvPair[K, V], key K, equal Equaler[K]) (*mapKvPair[K, V], bool) {
+	for iter := list; iter != nil; iter = iter.next {
+		if equal(iter.key, key) {
+			return copyKvListUntil(list, iter, iter.next), true
+		}
+	}
+	return list, false
+}
+
+// copyKvListUntil copies the nodes in list before the node stop, and links tail to the end of the copied nodes
+func copyKvListUntil[K, V any](list, stop, tail *mapKvPair[K, V]) *mapKvPair[K, V] {
+	if list == stop {
+		return tail
+	}
+	head := &mapKvPair[K, V]{key: list.key, value: list.value}
+	last := head
+	for iter := list.next; iter != stop; iter = iter.next {
+		last.next = &mapKvPair[K, V]{key: iter.key, value: iter.value}
+		last = last.next
+	}
+	last.next = tail
+	return head
+}
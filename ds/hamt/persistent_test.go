@@ -0,0 +1,116 @@
+package hamt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPersistentHamt(t *testing.T) {
+	h := NewPersistent[int]()
+	versions := make([]*PersistentHamt[int], 0)
+	for i := 0; i < 1000; i++ {
+		versions = append(versions, h)
+		h = h.Insert(Key(fmt.Sprintf("%07d", i)), i)
+	}
+	assert.Equal(t, 1000, h.Len())
+
+	for i, v := range versions {
+		assert.Equal(t, i, v.Len())
+		_, err := v.Get(Key(fmt.Sprintf("%07d", i)))
+		assert.Equal(t, ErrorNotFound, err)
+		if i > 0 {
+			val, _ := v.Get(Key(fmt.Sprintf("%07d", i-1)))
+			assert.Equal(t, i-1, val)
+		}
+	}
+
+	h2 := h.Insert(Key("0000001"), 100)
+	assert.Equal(t, 1000, h2.Len())
+	v, _ := h.Get(Key("0000001"))
+	assert.Equal(t, 1, v)
+	v, _ = h2.Get(Key("0000001"))
+	assert.Equal(t, 100, v)
+
+	h3 := h
+	for i := 0; i < 1000; i++ {
+		var ok bool
+		h3, ok = h3.Erase(Key(fmt.Sprintf("%07d", i)))
+		assert.True(t, ok)
+	}
+	assert.Equal(t, 0, h3.Len())
+	assert.Equal(t, 0, len(h3.Keys()))
+	_, ok := h3.Erase(Key("0000001"))
+	assert.False(t, ok)
+
+	assert.Equal(t, 1000, len(h.Keys()))
+	assert.Equal(t, 1000, len(h.StringKeys()))
+	h.Traversal(func(key Key, value int) bool {
+		assert.Equal(t, fmt.Sprintf("%07d", value), string(key))
+		return true
+	})
+}
+
+func TestTransientHamt(t *testing.T) {
+	th := NewPersistent[int]().Transient()
+	for i := 0; i < 1000; i++ {
+		th.Insert(Key(fmt.Sprintf("%d", i)), i)
+	}
+	snapshot := th.Snapshot()
+
+	for i := 0; i < 500; i++ {
+		assert.True(t, th.Erase(Key(fmt.Sprintf("%d", i))))
+		th.Insert(Key(fmt.Sprintf("%d", i+1000)), i+1000)
+	}
+	assert.False(t, th.Erase(Key("0")))
+	assert.Equal(t, 1000, th.Len())
+	assert.Equal(t, 1000, snapshot.Len())
+
+	for i := 0; i < 1000; i++ {
+		v, err := snapshot.Get(Key(fmt.Sprintf("%d", i)))
+		assert.Nil(t, err)
+		assert.Equal(t, i, v)
+	}
+	for i := 500; i < 1500; i++ {
+		v, err := th.Get(Key(fmt.Sprintf("%d", i)))
+		assert.Nil(t, err)
+		assert.Equal(t, i, v)
+	}
+
+	count := 0
+	th.Traversal(func(key Key, value int) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 1000, count)
+}
+
+func TestPersistentHamtCollision(t *testing.T) {
+	root := &mapBitmapNode[Key, int]{}
+	root, _ = root.assoc(nil, 0, 1, Key("a"), 1, BytesEqual[Key])
+	root, _ = root.assoc(nil, 0, 1, Key("b"), 2, BytesEqual[Key])
+	root, _ = root.assoc(nil, 0, 1, Key("c"), 3, BytesEqual[Key])
+	old := root
+	root, added := root.assoc(nil, 0, 1, Key("b"), 20, BytesEqual[Key])
+	assert.False(t, added)
+
+	v, _ := root.find(0, 1, Key("b"), BytesEqual[Key])
+	assert.Equal(t, 20, v)
+	v, _ = old.find(0, 1, Key("b"), BytesEqual[Key])
+	assert.Equal(t, 2, v)
+
+	root, removed := root.dissoc(nil, 0, 1, Key("c"), BytesEqual[Key])
+	assert.True(t, removed)
+	_, err := root.find(0, 1, Key("c"), BytesEqual[Key])
+	assert.Equal(t, ErrorNotFound, err)
+	v, _ = old.find(0, 1, Key("c"), BytesEqual[Key])
+	assert.Equal(t, 3, v)
+
+	// two hashes with the same lowest 6 bits
+	root, _ = root.assoc(nil, 0, 1|1<<6, Key("d"), 4, BytesEqual[Key])
+	v, _ = root.find(0, 1|1<<6, Key("d"), BytesEqual[Key])
+	assert.Equal(t, 4, v)
+	root, _ = root.dissoc(nil, 0, 1|1<<6, Key("d"), BytesEqual[Key])
+	assert.Equal(t, KV_NODE, root.children[0].Type())
+}
@@ -0,0 +1,241 @@
+package hashmap
+
+import (
+	gosync "sync"
+
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// Constants definition
+const (
+	DefaultShardCount    = 32
+	DefaultMaxLoadFactor = 0.875
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Hasher is a function used to calculate the hash value of a key,
+// the hashers in package hamt such as hamt.OrderedHasher can be used
+type Hasher[K any] func(key K) uint64
+
+// Options holds the options of Map, RobinHoodMap and RobinHoodSet
+type Options struct {
+	shards        int
+	maxLoadFactor float64
+	locker        sync.Locker
+}
+
+// Option is a function type used to set Options
+type Option func(option *Options)
+
+// WithShards sets the amount of shards of a Map, it is rounded up to a power of 2. The default is DefaultShardCount
+func WithShards(n int) Option {
+	return func(option *Options) {
+		option.shards = n
+	}
+}
+
+// WithMaxLoadFactor sets the max load factor of a RobinHoodMap or RobinHoodSet, the table grows when the load factor
+// would exceed it. It must be in range (0, 1), the default is DefaultMaxLoadFactor
+func WithMaxLoadFactor(f float64) Option {
+	return func(option *Options) {
+		if f > 0 && f < 1 {
+			option.maxLoadFactor = f
+		}
+	}
+}
+
+// WithGoroutineSafe is used to set a RobinHoodMap or RobinHoodSet goroutine-safe, a Map is always goroutine-safe
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// shard is a part of the Map protected by its own lock
+type shard[K comparable, V any] struct {
+	locker sync.Locker
+	items  map[K]V
+	_      [64]byte // avoid false sharing between neighbouring shards
+}
+
+// Map is a goroutine-safe hash map, the keys are distributed over shards by their hash values,
+// and each shard is protected by its own lock, so operations on keys in different shards don't contend
+type Map[K comparable, V any] struct {
+	shards []shard[K, V]
+	mask   uint64
+	hasher Hasher[K]
+}
+
+// New creates a Map with the passed hasher
+func New[K comparable, V any](hasher Hasher[K], opts ...Option) *Map[K, V] {
+	option := Options{
+		shards: DefaultShardCount,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	n := 1
+	for n < option.shards {
+		n <<= 1
+	}
+	m := &Map[K, V]{
+		shards: make([]shard[K, V], n),
+		mask:   uint64(n - 1),
+		hasher: hasher,
+	}
+	for i := range m.shards {
+		m.shards[i].locker = &gosync.RWMutex{}
+		m.shards[i].items = make(map[K]V)
+	}
+	return m
+}
+
+func (m *Map[K, V]) shardOf(key K) *shard[K, V] {
+	h := m.hasher(key)
+	// the high bits are mixed in because weak hashers may only spread the low bits
+	return &m.shards[(h^h>>32)&m.mask]
+}
+
+// Load returns the value stored in the Map for the key, and true if the key is found
+func (m *Map[K, V]) Load(key K) (V, bool) {
+	s := m.shardOf(key)
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	v, ok := s.items[key]
+	return v, ok
+}
+
+// Store sets the value for the key
+func (m *Map[K, V]) Store(key K, value V) {
+	s := m.shardOf(key)
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.items[key] = value
+}
+
+// LoadOrStore returns the existing value for the key if present and true,
+// otherwise it stores the passed value and returns it and false
+func (m *Map[K, V]) LoadOrStore(key K, value V) (V, bool) {
+	s := m.shardOf(key)
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if v, ok := s.items[key]; ok {
+		return v, true
+	}
+	s.items[key] = value
+	return value, false
+}
+
+// LoadAndDelete deletes the key and returns its previous value and true if the key is found
+func (m *Map[K, V]) LoadAndDelete(key K) (V, bool) {
+	s := m.shardOf(key)
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	v, ok := s.items[key]
+	if ok {
+		delete(s.items, key)
+	}
+	return v, ok
+}
+
+// Delete deletes the key and returns true if the key is found
+func (m *Map[K, V]) Delete(key K) bool {
+	_, ok := m.LoadAndDelete(key)
+	return ok
+}
+
+// Compute atomically computes a new value for the key. fn is called with the current value and whether the key
+// is present, if fn returns keep with false the key is deleted, otherwise the returned value is stored.
+// It returns the new value and keep. fn is called with the shard locked, so it must not call methods of the Map
+func (m *Map[K, V]) Compute(key K, fn func(old V, loaded bool) (value V, keep bool)) (V, bool) {
+	s := m.shardOf(key)
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	old, loaded := s.items[key]
+	value, keep := fn(old, loaded)
+	if keep {
+		s.items[key] = value
+	} else if loaded {
+		delete(s.items, key)
+	}
+	return value, keep
+}
+
+// Merge stores the value if the key is absent, otherwise it stores fn(old, value). It returns the stored value.
+// fn is called with the shard locked, so it must not call methods of the Map
+func (m *Map[K, V]) Merge(key K, value V, fn func(old, value V) V) V {
+	s := m.shardOf(key)
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if old, ok := s.items[key]; ok {
+		value = fn(old, value)
+	}
+	s.items[key] = value
+	return value
+}
+
+// Range calls the visitor for each key-value pair until the visitor returns false.
+// Each shard is copied under its lock before being visited, so the pairs of one shard form a consistent snapshot,
+// but modifications to other shards made during Range may or may not be observed. The visitor may modify the Map
+func (m *Map[K, V]) Range(visitor visitor.KvVisitor[K, V]) {
+	var keys []K
+	var values []V
+	for i := range m.shards {
+		s := &m.shards[i]
+		keys, values = keys[:0], values[:0]
+		s.locker.RLock()
+		for k, v := range s.items {
+			keys = append(keys, k)
+			values = append(values, v)
+		}
+		s.locker.RUnlock()
+
+		for j := range keys {
+			if !visitor(keys[j], values[j]) {
+				return
+			}
+		}
+	}
+}
+
+// Size returns the amount of keys in the Map, it is not a snapshot if the Map is modified concurrently
+func (m *Map[K, V]) Size() int {
+	size := 0
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.locker.RLock()
+		size += len(s.items)
+		s.locker.RUnlock()
+	}
+	return size
+}
+
+// Empty returns true if the Map is empty
+func (m *Map[K, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+// Clear removes all keys in the Map
+func (m *Map[K, V]) Clear() {
+	for i := range m.shards {
+		s := &m.shards[i]
+		s.locker.Lock()
+		s.items = make(map[K]V)
+		s.locker.Unlock()
+	}
+}
+
+// ShardCount returns the amount of shards
+func (m *Map[K, V]) ShardCount() int {
+	return len(m.shards)
+}
@@ -0,0 +1,192 @@
+package hashmap
+
+import (
+	"strconv"
+	gosync "sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/liyue201/gostl/ds/hamt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMap(t *testing.T) {
+	m := New[int, string](hamt.OrderedHasher[int], WithShards(5))
+	assert.Equal(t, 8, m.ShardCount())
+	assert.True(t, m.Empty())
+
+	for i := 0; i < 100; i++ {
+		m.Store(i, strconv.Itoa(i))
+	}
+	assert.Equal(t, 100, m.Size())
+	v, ok := m.Load(42)
+	assert.True(t, ok)
+	assert.Equal(t, "42", v)
+	_, ok = m.Load(100)
+	assert.False(t, ok)
+
+	v, loaded := m.LoadOrStore(42, "x")
+	assert.True(t, loaded)
+	assert.Equal(t, "42", v)
+	v, loaded = m.LoadOrStore(100, "100")
+	assert.False(t, loaded)
+	assert.Equal(t, "100", v)
+
+	v, ok = m.LoadAndDelete(100)
+	assert.True(t, ok)
+	assert.Equal(t, "100", v)
+	assert.False(t, m.Delete(100))
+	assert.True(t, m.Delete(99))
+	assert.Equal(t, 99, m.Size())
+
+	m.Clear()
+	assert.True(t, m.Empty())
+}
+
+func TestComputeMerge(t *testing.T) {
+	m := New[string, int](hamt.StringHasher[string])
+
+	inc := func(old int, loaded bool) (int, bool) {
+		return old + 1, true
+	}
+	v, ok := m.Compute("a", inc)
+	assert.True(t, ok)
+	assert.Equal(t, 1, v)
+	v, _ = m.Compute("a", inc)
+	assert.Equal(t, 2, v)
+
+	_, ok = m.Compute("a", func(old int, loaded bool) (int, bool) {
+		assert.True(t, loaded)
+		return 0, false
+	})
+	assert.False(t, ok)
+	_, ok = m.Load("a")
+	assert.False(t, ok)
+
+	_, ok = m.Compute("b", func(old int, loaded bool) (int, bool) {
+		assert.False(t, loaded)
+		return 0, false
+	})
+	assert.False(t, ok)
+	assert.Equal(t, 0, m.Size())
+
+	sum := func(old, value int) int { return old + value }
+	assert.Equal(t, 3, m.Merge("c", 3, sum))
+	assert.Equal(t, 7, m.Merge("c", 4, sum))
+	v, _ = m.Load("c")
+	assert.Equal(t, 7, v)
+}
+
+func TestRange(t *testing.T) {
+	m := New[int, int](hamt.OrderedHasher[int], WithShards(4))
+	for i := 0; i < 1000; i++ {
+		m.Store(i, i*2)
+	}
+	seen := make(map[int]int)
+	m.Range(func(k, v int) bool {
+		seen[k] = v
+		// modifying the map in the visitor doesn't deadlock
+		m.Store(k, v+1)
+		return true
+	})
+	assert.Equal(t, 1000, len(seen))
+	for k, v := range seen {
+		assert.Equal(t, k*2, v)
+	}
+
+	n := 0
+	m.Range(func(k, v int) bool {
+		n++
+		return n < 10
+	})
+	assert.Equal(t, 10, n)
+}
+
+func TestConcurrent(t *testing.T) {
+	m := New[int, int](hamt.OrderedHasher[int])
+	wg := gosync.WaitGroup{}
+	var stored int64
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 1000; i++ {
+				m.Merge(i%100, 1, func(old, value int) int { return old + value })
+				if _, loaded := m.LoadOrStore(1000+i, i); !loaded {
+					atomic.AddInt64(&stored, 1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, int64(1000), stored)
+	assert.Equal(t, 1100, m.Size())
+	for i := 0; i < 100; i++ {
+		v, _ := m.Load(i)
+		assert.Equal(t, 80, v)
+	}
+}
+
+const benchKeys = 1 << 12
+
+func BenchmarkMapLoadStore(b *testing.B) {
+	m := New[int, int](hamt.OrderedHasher[int])
+	for i := 0; i < benchKeys; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := (i * 31) & (benchKeys - 1)
+			if i%10 == 0 {
+				m.Store(k, i)
+			} else {
+				m.Load(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapLoadStore(b *testing.B) {
+	var m gosync.Map
+	for i := 0; i < benchKeys; i++ {
+		m.Store(i, i)
+	}
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			k := (i * 31) & (benchKeys - 1)
+			if i%10 == 0 {
+				m.Store(k, i)
+			} else {
+				m.Load(k)
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkMapLoadOrStore(b *testing.B) {
+	m := New[int, int](hamt.OrderedHasher[int])
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.LoadOrStore((i*31)&(benchKeys-1), i)
+			i++
+		}
+	})
+}
+
+func BenchmarkSyncMapLoadOrStore(b *testing.B) {
+	var m gosync.Map
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			m.LoadOrStore((i*31)&(benchKeys-1), i)
+			i++
+		}
+	})
+}
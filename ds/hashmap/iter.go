@@ -0,0 +1,26 @@
+//go:build go1.23
+
+package hashmap
+
+import "iter"
+
+// All returns an iterator over key-value pairs in the Map, it has the same consistency as Range
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Range(yield)
+	}
+}
+
+// All returns an iterator over key-value pairs in the RobinHoodMap, in insertion order
+func (m *RobinHoodMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.Traversal(yield)
+	}
+}
+
+// Values returns an iterator over elements in the RobinHoodSet, in insertion order
+func (s *RobinHoodSet[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.Traversal(yield)
+	}
+}
@@ -0,0 +1,44 @@
+//go:build go1.23
+
+package hashmap
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/ds/hamt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	m := New[int, int](hamt.OrderedHasher[int])
+	for i := 0; i < 100; i++ {
+		m.Store(i, -i)
+	}
+	sum := 0
+	for k, v := range m.All() {
+		assert.Equal(t, -k, v)
+		sum += k
+	}
+	assert.Equal(t, 4950, sum)
+}
+
+func TestRobinHoodAll(t *testing.T) {
+	m := NewRobinHoodMap[int, int](hamt.OrderedHasher[int])
+	s := NewRobinHoodSet[int](hamt.OrderedHasher[int])
+	for i := 10; i > 0; i-- {
+		m.Insert(i, -i)
+		s.Insert(i)
+	}
+	var keys []int
+	for k, v := range m.All() {
+		assert.Equal(t, -k, v)
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{10, 9, 8, 7, 6, 5, 4, 3, 2, 1}, keys)
+
+	var elems []int
+	for e := range s.Values() {
+		elems = append(elems, e)
+	}
+	assert.Equal(t, keys, elems)
+}
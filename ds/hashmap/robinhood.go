@@ -0,0 +1,354 @@
+package hashmap
+
+import (
+	"errors"
+	"unsafe"
+
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// ErrorNotFound is returned by RobinHoodMap.Get if the key is not in the map
+var ErrorNotFound = errors.New("not found")
+
+const minSlots = 8
+
+// rhSlot is a slot of the probing table, it points to an entry
+type rhSlot struct {
+	hash  uint64
+	entry int32
+	dist  int32 // probe distance + 1, 0 means the slot is empty
+}
+
+// rhEntry is a key-value pair stored in insertion order
+type rhEntry[K comparable, V any] struct {
+	key     K
+	value   V
+	hash    uint64
+	deleted bool
+}
+
+// Stats holds the memory and probing statistics of a RobinHoodMap
+type Stats struct {
+	Size          int     // amount of keys
+	Slots         int     // amount of slots of the probing table
+	Entries       int     // amount of entries, including the erased ones not compacted yet
+	LoadFactor    float64 // Size / Slots
+	MaxProbe      int     // the max probe distance
+	AvgProbe      float64 // the average probe distance
+	Bytes         int     // memory used by the slots and entries
+	MaxLoadFactor float64
+}
+
+// RobinHoodMap is an open-addressing hash map using Robin Hood probing and backward-shift deletion.
+// Key-value pairs are stored in a dense array in insertion order, and a probing table of slots points to them,
+// so iteration follows insertion order. Erasing a key leaves a hole in the array which is compacted by a later
+// Insert or Reserve, so iterators stay valid across Erase but not across Insert, Reserve or Clear
+type RobinHoodMap[K comparable, V any] struct {
+	slots         []rhSlot
+	entries       []rhEntry[K, V]
+	size          int
+	hasher        Hasher[K]
+	maxLoadFactor float64
+	locker        sync.Locker
+}
+
+// NewRobinHoodMap creates a RobinHoodMap with the passed hasher
+func NewRobinHoodMap[K comparable, V any](hasher Hasher[K], opts ...Option) *RobinHoodMap[K, V] {
+	option := Options{
+		maxLoadFactor: DefaultMaxLoadFactor,
+		locker:        defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &RobinHoodMap[K, V]{
+		hasher:        hasher,
+		maxLoadFactor: option.maxLoadFactor,
+		locker:        option.locker,
+	}
+}
+
+// Insert inserts a key-value pair into the map, the value is replaced if the key is already in the map
+func (m *RobinHoodMap[K, V]) Insert(key K, value V) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.insert(key, value)
+}
+
+// Get returns the value of the key if the key is in the map, otherwise returns ErrorNotFound
+func (m *RobinHoodMap[K, V]) Get(key K) (V, error) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	if i := m.find(key); i >= 0 {
+		return m.entries[m.slots[i].entry].value, nil
+	}
+	return *new(V), ErrorNotFound
+}
+
+// Contains returns true if the key is in the map
+func (m *RobinHoodMap[K, V]) Contains(key K) bool {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.find(key) >= 0
+}
+
+// Erase erases the key from the map and returns true if the key is in the map
+func (m *RobinHoodMap[K, V]) Erase(key K) bool {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	i := m.find(key)
+	if i < 0 {
+		return false
+	}
+	m.eraseSlot(i)
+	return true
+}
+
+// Find returns an iterator pointing to the key, the iterator is invalid if the key is not in the map
+func (m *RobinHoodMap[K, V]) Find(key K) *RobinHoodMapIterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	if i := m.find(key); i >= 0 {
+		return &RobinHoodMapIterator[K, V]{m: m, index: int(m.slots[i].entry)}
+	}
+	return &RobinHoodMapIterator[K, V]{m: m, index: len(m.entries)}
+}
+
+// Reserve makes the map able to hold n keys without growing
+func (m *RobinHoodMap[K, V]) Reserve(n int) {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	if slots := m.slotsFor(n); slots > len(m.slots) || n > cap(m.entries) {
+		m.rehash(slots, n)
+	}
+}
+
+// Clear removes all keys and keeps the allocated memory
+func (m *RobinHoodMap[K, V]) Clear() {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	for i := range m.slots {
+		m.slots[i] = rhSlot{}
+	}
+	var zero rhEntry[K, V]
+	for i := range m.entries {
+		m.entries[i] = zero
+	}
+	m.entries = m.entries[:0]
+	m.size = 0
+}
+
+// Size returns the amount of keys in the map
+func (m *RobinHoodMap[K, V]) Size() int {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return m.size
+}
+
+// Empty returns true if the map is empty
+func (m *RobinHoodMap[K, V]) Empty() bool {
+	return m.Size() == 0
+}
+
+// Capacity returns the amount of keys the map can hold without growing
+func (m *RobinHoodMap[K, V]) Capacity() int {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return int(float64(len(m.slots)) * m.maxLoadFactor)
+}
+
+// LoadFactor returns the ratio of the amount of keys to the amount of slots
+func (m *RobinHoodMap[K, V]) LoadFactor() float64 {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	if len(m.slots) == 0 {
+		return 0
+	}
+	return float64(m.size) / float64(len(m.slots))
+}
+
+// Stats returns the memory and probing statistics of the map
+func (m *RobinHoodMap[K, V]) Stats() Stats {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	stats := Stats{
+		Size:          m.size,
+		Slots:         len(m.slots),
+		Entries:       len(m.entries),
+		MaxLoadFactor: m.maxLoadFactor,
+		Bytes:         cap(m.slots)*int(unsafe.Sizeof(rhSlot{})) + cap(m.entries)*int(unsafe.Sizeof(rhEntry[K, V]{})),
+	}
+	if len(m.slots) == 0 {
+		return stats
+	}
+	stats.LoadFactor = float64(m.size) / float64(len(m.slots))
+	total := 0
+	for _, s := range m.slots {
+		if s.dist == 0 {
+			continue
+		}
+		dist := int(s.dist) - 1
+		total += dist
+		if dist > stats.MaxProbe {
+			stats.MaxProbe = dist
+		}
+	}
+	if m.size > 0 {
+		stats.AvgProbe = float64(total) / float64(m.size)
+	}
+	return stats
+}
+
+// Traversal traversals key-value pairs in insertion order, it will not stop until to the end or the visitor returns false
+func (m *RobinHoodMap[K, V]) Traversal(visitor visitor.KvVisitor[K, V]) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	for i := range m.entries {
+		e := &m.entries[i]
+		if !e.deleted && !visitor(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// Begin returns an iterator pointing to the first inserted key
+func (m *RobinHoodMap[K, V]) Begin() *RobinHoodMapIterator[K, V] {
+	return m.First()
+}
+
+// First returns an iterator pointing to the first inserted key
+func (m *RobinHoodMap[K, V]) First() *RobinHoodMapIterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return &RobinHoodMapIterator[K, V]{m: m, index: m.next(-1)}
+}
+
+// Last returns an iterator pointing to the last inserted key
+func (m *RobinHoodMap[K, V]) Last() *RobinHoodMapIterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return &RobinHoodMapIterator[K, V]{m: m, index: m.prev(len(m.entries))}
+}
+
+// find returns the index of the slot pointing to the key, or -1 if the key is not in the map
+func (m *RobinHoodMap[K, V]) find(key K) int {
+	if m.size == 0 {
+		return -1
+	}
+	h := m.hasher(key)
+	mask := len(m.slots) - 1
+	for i, dist := int(h)&mask, int32(1); ; i, dist = (i+1)&mask, dist+1 {
+		s := &m.slots[i]
+		// a key can't be further than a slot which is empty or closer to its home
+		if s.dist < dist {
+			return -1
+		}
+		if s.hash == h && m.entries[s.entry].key == key {
+			return i
+		}
+	}
+}
+
+func (m *RobinHoodMap[K, V]) insert(key K, value V) {
+	if i := m.find(key); i >= 0 {
+		m.entries[m.slots[i].entry].value = value
+		return
+	}
+	if m.size+1 > int(float64(len(m.slots))*m.maxLoadFactor) {
+		m.rehash(m.slotsFor(m.size+1), 0)
+	} else if len(m.entries) >= 2*m.size+minSlots {
+		// too many holes left by Erase
+		m.rehash(len(m.slots), 0)
+	}
+	h := m.hasher(key)
+	m.entries = append(m.entries, rhEntry[K, V]{key: key, value: value, hash: h})
+	m.place(rhSlot{hash: h, entry: int32(len(m.entries) - 1), dist: 1})
+	m.size++
+}
+
+// place puts the slot into the table, it takes the place of any slot closer to its home along the probe sequence
+func (m *RobinHoodMap[K, V]) place(cur rhSlot) {
+	mask := len(m.slots) - 1
+	for i := int(cur.hash) & mask; ; i = (i + 1) & mask {
+		s := &m.slots[i]
+		if s.dist == 0 {
+			*s = cur
+			return
+		}
+		if s.dist < cur.dist {
+			*s, cur = cur, *s
+		}
+		cur.dist++
+	}
+}
+
+// eraseSlot erases the entry pointed to by slot i, and shifts the following slots backward
+// until a slot which is empty or at its home
+func (m *RobinHoodMap[K, V]) eraseSlot(i int) {
+	e := &m.entries[m.slots[i].entry]
+	*e = rhEntry[K, V]{deleted: true}
+	m.size--
+
+	mask := len(m.slots) - 1
+	for j := (i + 1) & mask; m.slots[j].dist > 1; i, j = j, (j+1)&mask {
+		m.slots[i] = m.slots[j]
+		m.slots[i].dist--
+	}
+	m.slots[i] = rhSlot{}
+}
+
+// slotsFor returns the amount of slots needed to hold n keys
+func (m *RobinHoodMap[K, V]) slotsFor(n int) int {
+	slots := minSlots
+	for int(float64(slots)*m.maxLoadFactor) < n {
+		slots <<= 1
+	}
+	return slots
+}
+
+// rehash rebuilds the table with the passed amount of slots, and compacts the entries
+func (m *RobinHoodMap[K, V]) rehash(slots, entryCap int) {
+	if entryCap < m.size {
+		entryCap = m.size
+	}
+	entries := make([]rhEntry[K, V], 0, entryCap)
+	for _, e := range m.entries {
+		if !e.deleted {
+			entries = append(entries, e)
+		}
+	}
+	m.entries = entries
+	m.slots = make([]rhSlot, slots)
+	for i := range m.entries {
+		m.place(rhSlot{hash: m.entries[i].hash, entry: int32(i), dist: 1})
+	}
+}
+
+// next returns the index of the first entry after index which is not erased
+func (m *RobinHoodMap[K, V]) next(index int) int {
+	for index++; index < len(m.entries) && m.entries[index].deleted; index++ {
+	}
+	return index
+}
+
+// prev returns the index of the last entry before index which is not erased, or -1
+func (m *RobinHoodMap[K, V]) prev(index int) int {
+	for index--; index >= 0 && m.entries[index].deleted; index-- {
+	}
+	return index
+}
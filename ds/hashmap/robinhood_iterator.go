@@ -0,0 +1,100 @@
+package hashmap
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+var _ iterator.KvBidIterator[int, int] = (*RobinHoodMapIterator[int, int])(nil)
+var _ iterator.ConstBidIterator[int] = (*RobinHoodSetIterator[int])(nil)
+
+// RobinHoodMapIterator is an iterator of RobinHoodMap which visits key-value pairs in insertion order
+type RobinHoodMapIterator[K comparable, V any] struct {
+	m     *RobinHoodMap[K, V]
+	index int
+}
+
+// IsValid returns true if the iterator is valid, otherwise returns false
+func (iter *RobinHoodMapIterator[K, V]) IsValid() bool {
+	return iter.index >= 0 && iter.index < len(iter.m.entries) && !iter.m.entries[iter.index].deleted
+}
+
+// Next moves the iterator to the next key-value pair, and returns itself
+func (iter *RobinHoodMapIterator[K, V]) Next() iterator.ConstIterator[V] {
+	if iter.index < len(iter.m.entries) {
+		iter.index = iter.m.next(iter.index)
+	}
+	return iter
+}
+
+// Prev moves the iterator to the previous key-value pair, and returns itself
+func (iter *RobinHoodMapIterator[K, V]) Prev() iterator.ConstBidIterator[V] {
+	if iter.index >= 0 {
+		iter.index = iter.m.prev(iter.index)
+	}
+	return iter
+}
+
+// Key returns the key of the iterator point to
+func (iter *RobinHoodMapIterator[K, V]) Key() K {
+	return iter.m.entries[iter.index].key
+}
+
+// Value returns the value of the iterator point to
+func (iter *RobinHoodMapIterator[K, V]) Value() V {
+	return iter.m.entries[iter.index].value
+}
+
+// SetValue sets the value of the iterator point to
+func (iter *RobinHoodMapIterator[K, V]) SetValue(value V) {
+	iter.m.entries[iter.index].value = value
+}
+
+// Clone clones the iterator to a new RobinHoodMapIterator
+func (iter *RobinHoodMapIterator[K, V]) Clone() iterator.ConstIterator[V] {
+	return &RobinHoodMapIterator[K, V]{m: iter.m, index: iter.index}
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *RobinHoodMapIterator[K, V]) Equal(other iterator.ConstIterator[V]) bool {
+	otherIter, ok := other.(*RobinHoodMapIterator[K, V])
+	if !ok {
+		return false
+	}
+	return otherIter.m == iter.m && otherIter.index == iter.index
+}
+
+// RobinHoodSetIterator is an iterator of RobinHoodSet which visits elements in insertion order
+type RobinHoodSetIterator[T comparable] struct {
+	RobinHoodMapIterator[T, struct{}]
+}
+
+// Next moves the iterator to the next element, and returns itself
+func (iter *RobinHoodSetIterator[T]) Next() iterator.ConstIterator[T] {
+	iter.RobinHoodMapIterator.Next()
+	return iter
+}
+
+// Prev moves the iterator to the previous element, and returns itself
+func (iter *RobinHoodSetIterator[T]) Prev() iterator.ConstBidIterator[T] {
+	iter.RobinHoodMapIterator.Prev()
+	return iter
+}
+
+// Value returns the element of the iterator point to
+func (iter *RobinHoodSetIterator[T]) Value() T {
+	return iter.Key()
+}
+
+// Clone clones the iterator to a new RobinHoodSetIterator
+func (iter *RobinHoodSetIterator[T]) Clone() iterator.ConstIterator[T] {
+	return &RobinHoodSetIterator[T]{RobinHoodMapIterator[T, struct{}]{m: iter.m, index: iter.index}}
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *RobinHoodSetIterator[T]) Equal(other iterator.ConstIterator[T]) bool {
+	otherIter, ok := other.(*RobinHoodSetIterator[T])
+	if !ok {
+		return false
+	}
+	return otherIter.m == iter.m && otherIter.index == iter.index
+}
@@ -0,0 +1,92 @@
+package hashmap
+
+import (
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// RobinHoodSet is an open-addressing hash set built on RobinHoodMap, the elements are iterated in insertion order
+type RobinHoodSet[T comparable] struct {
+	m *RobinHoodMap[T, struct{}]
+}
+
+// NewRobinHoodSet creates a RobinHoodSet with the passed hasher
+func NewRobinHoodSet[T comparable](hasher Hasher[T], opts ...Option) *RobinHoodSet[T] {
+	return &RobinHoodSet[T]{m: NewRobinHoodMap[T, struct{}](hasher, opts...)}
+}
+
+// Insert inserts an element into the set
+func (s *RobinHoodSet[T]) Insert(element T) {
+	s.m.Insert(element, struct{}{})
+}
+
+// Erase erases the element from the set and returns true if the element is in the set
+func (s *RobinHoodSet[T]) Erase(element T) bool {
+	return s.m.Erase(element)
+}
+
+// Contains returns true if the element is in the set
+func (s *RobinHoodSet[T]) Contains(element T) bool {
+	return s.m.Contains(element)
+}
+
+// Find returns an iterator pointing to the element, the iterator is invalid if the element is not in the set
+func (s *RobinHoodSet[T]) Find(element T) *RobinHoodSetIterator[T] {
+	return &RobinHoodSetIterator[T]{*s.m.Find(element)}
+}
+
+// Reserve makes the set able to hold n elements without growing
+func (s *RobinHoodSet[T]) Reserve(n int) {
+	s.m.Reserve(n)
+}
+
+// Clear removes all elements and keeps the allocated memory
+func (s *RobinHoodSet[T]) Clear() {
+	s.m.Clear()
+}
+
+// Size returns the amount of elements in the set
+func (s *RobinHoodSet[T]) Size() int {
+	return s.m.Size()
+}
+
+// Empty returns true if the set is empty
+func (s *RobinHoodSet[T]) Empty() bool {
+	return s.m.Empty()
+}
+
+// Capacity returns the amount of elements the set can hold without growing
+func (s *RobinHoodSet[T]) Capacity() int {
+	return s.m.Capacity()
+}
+
+// LoadFactor returns the ratio of the amount of elements to the amount of slots
+func (s *RobinHoodSet[T]) LoadFactor() float64 {
+	return s.m.LoadFactor()
+}
+
+// Stats returns the memory and probing statistics of the set
+func (s *RobinHoodSet[T]) Stats() Stats {
+	return s.m.Stats()
+}
+
+// Traversal traversals elements in insertion order, it will not stop until to the end or the visitor returns false
+func (s *RobinHoodSet[T]) Traversal(visitor visitor.Visitor[T]) {
+	s.m.Traversal(func(key T, _ struct{}) bool {
+		return visitor(key)
+	})
+}
+
+// Begin returns an iterator pointing to the first inserted element
+func (s *RobinHoodSet[T]) Begin() *RobinHoodSetIterator[T] {
+	return s.First()
+}
+
+// First returns an iterator pointing to the first inserted element
+func (s *RobinHoodSet[T]) First() *RobinHoodSetIterator[T] {
+	return &RobinHoodSetIterator[T]{*s.m.First()}
+}
+
+// Last returns an iterator pointing to the last inserted element
+func (s *RobinHoodSet[T]) Last() *RobinHoodSetIterator[T] {
+	return &RobinHoodSetIterator[T]{*s.m.Last()}
+}
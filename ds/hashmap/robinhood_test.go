@@ -0,0 +1,251 @@
+package hashmap
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/liyue201/gostl/ds/hamt"
+	"github.com/stretchr/testify/assert"
+)
+
+// checkTable checks the Robin Hood invariants of the probing table
+func checkTable[K comparable, V any](t *testing.T, m *RobinHoodMap[K, V]) {
+	mask := len(m.slots) - 1
+	n := 0
+	for i, s := range m.slots {
+		if s.dist == 0 {
+			continue
+		}
+		n++
+		e := m.entries[s.entry]
+		assert.False(t, e.deleted)
+		assert.Equal(t, e.hash, s.hash)
+		assert.Equal(t, int32((i-int(s.hash)&mask)&mask)+1, s.dist)
+		// the previous slot is either at most one step closer to its home or the home of this slot
+		if prev := m.slots[(i-1)&mask]; s.dist > 1 {
+			assert.GreaterOrEqual(t, prev.dist, s.dist-1)
+		}
+	}
+	assert.Equal(t, m.size, n)
+}
+
+func TestRobinHoodMap(t *testing.T) {
+	m := NewRobinHoodMap[string, int](hamt.StringHasher[string])
+	assert.True(t, m.Empty())
+	_, err := m.Get("a")
+	assert.Equal(t, ErrorNotFound, err)
+	assert.False(t, m.Erase("a"))
+
+	m.Insert("a", 1)
+	m.Insert("b", 2)
+	m.Insert("c", 3)
+	m.Insert("a", 10)
+	assert.Equal(t, 3, m.Size())
+	v, err := m.Get("a")
+	assert.Nil(t, err)
+	assert.Equal(t, 10, v)
+	assert.True(t, m.Contains("b"))
+
+	assert.True(t, m.Erase("b"))
+	assert.False(t, m.Contains("b"))
+	assert.Equal(t, 2, m.Size())
+
+	m.Insert("b", 20)
+	var keys []string
+	m.Traversal(func(key string, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []string{"a", "c", "b"}, keys)
+	checkTable(t, m)
+
+	m.Clear()
+	assert.Equal(t, 0, m.Size())
+	assert.False(t, m.First().IsValid())
+	assert.False(t, m.Contains("a"))
+}
+
+func TestRobinHoodCollisions(t *testing.T) {
+	// all keys share a few home slots
+	m := NewRobinHoodMap[int, int](func(key int) uint64 { return uint64(key % 3) })
+	for i := 0; i < 50; i++ {
+		m.Insert(i, i)
+	}
+	checkTable(t, m)
+	for i := 0; i < 50; i += 2 {
+		assert.True(t, m.Erase(i))
+		checkTable(t, m)
+	}
+	for i := 0; i < 50; i++ {
+		v, err := m.Get(i)
+		if i%2 == 0 {
+			assert.Equal(t, ErrorNotFound, err)
+		} else {
+			assert.Equal(t, i, v)
+		}
+	}
+}
+
+func TestRobinHoodRandom(t *testing.T) {
+	m := NewRobinHoodMap[int, int](hamt.OrderedHasher[int], WithMaxLoadFactor(0.9))
+	ref := make(map[int]int)
+	var order []int
+	for i := 0; i < 20000; i++ {
+		k := rand.Intn(2000)
+		if rand.Intn(3) == 0 {
+			_, ok := ref[k]
+			assert.Equal(t, ok, m.Erase(k))
+			if ok {
+				delete(ref, k)
+				for j, o := range order {
+					if o == k {
+						order = append(order[:j], order[j+1:]...)
+						break
+					}
+				}
+			}
+		} else {
+			if _, ok := ref[k]; !ok {
+				order = append(order, k)
+			}
+			ref[k] = i
+			m.Insert(k, i)
+		}
+		if i%1000 == 0 {
+			checkTable(t, m)
+		}
+	}
+	checkTable(t, m)
+	assert.Equal(t, len(ref), m.Size())
+	assert.LessOrEqual(t, m.LoadFactor(), 0.9)
+
+	var keys []int
+	for iter := m.Begin(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, ref[iter.Key()], iter.Value())
+		keys = append(keys, iter.Key())
+	}
+	assert.Equal(t, order, keys)
+}
+
+func TestRobinHoodReserve(t *testing.T) {
+	m := NewRobinHoodMap[int, int](hamt.OrderedHasher[int], WithMaxLoadFactor(0.5))
+	m.Reserve(1000)
+	assert.GreaterOrEqual(t, m.Capacity(), 1000)
+	stats := m.Stats()
+	assert.Equal(t, 2048, stats.Slots)
+	assert.Equal(t, 0.5, stats.MaxLoadFactor)
+
+	for i := 0; i < 1000; i++ {
+		m.Insert(i, i)
+	}
+	stats = m.Stats()
+	assert.Equal(t, 2048, stats.Slots)
+	assert.Equal(t, 1000, stats.Size)
+	assert.Equal(t, 1000, stats.Entries)
+	assert.InDelta(t, 1000.0/2048, stats.LoadFactor, 1e-9)
+	assert.Equal(t, 2048*16+1000*32, stats.Bytes)
+	assert.GreaterOrEqual(t, float64(stats.MaxProbe), stats.AvgProbe)
+
+	// erased entries are compacted by a later insertion
+	for i := 0; i < 900; i++ {
+		m.Erase(i)
+	}
+	assert.Equal(t, 1000, m.Stats().Entries)
+	m.Insert(-1, -1)
+	assert.Equal(t, 101, m.Stats().Entries)
+	checkTable(t, m)
+}
+
+func TestRobinHoodIterator(t *testing.T) {
+	m := NewRobinHoodMap[int, string](hamt.OrderedHasher[int])
+	for i := 0; i < 10; i++ {
+		m.Insert(i, "v")
+	}
+	m.Erase(0)
+	m.Erase(5)
+	m.Erase(9)
+
+	// erasing keeps iterators valid
+	var keys []int
+	for iter := m.First(); iter.IsValid(); iter.Next() {
+		keys = append(keys, iter.Key())
+		if iter.Key() == 3 {
+			m.Erase(4)
+		}
+	}
+	assert.Equal(t, []int{1, 2, 3, 6, 7, 8}, keys)
+
+	keys = keys[:0]
+	for iter := m.Last(); iter.IsValid(); iter.Prev() {
+		keys = append(keys, iter.Key())
+	}
+	assert.Equal(t, []int{8, 7, 6, 3, 2, 1}, keys)
+
+	iter := m.Find(6)
+	assert.True(t, iter.IsValid())
+	iter.SetValue("six")
+	v, _ := m.Get(6)
+	assert.Equal(t, "six", v)
+	assert.False(t, m.Find(5).IsValid())
+
+	clone := iter.Clone().(*RobinHoodMapIterator[int, string])
+	assert.True(t, clone.Equal(iter))
+	clone.Next()
+	assert.Equal(t, 7, clone.Key())
+	assert.False(t, clone.Equal(iter))
+}
+
+func TestRobinHoodSet(t *testing.T) {
+	s := NewRobinHoodSet[string](hamt.StringHasher[string], WithGoroutineSafe())
+	for _, e := range []string{"c", "a", "b", "a"} {
+		s.Insert(e)
+	}
+	assert.Equal(t, 3, s.Size())
+	assert.True(t, s.Contains("a"))
+	assert.True(t, s.Erase("a"))
+	assert.False(t, s.Erase("a"))
+	s.Insert("a")
+
+	var elems []string
+	for iter := s.Begin(); iter.IsValid(); iter.Next() {
+		elems = append(elems, iter.Value())
+	}
+	assert.Equal(t, []string{"c", "b", "a"}, elems)
+
+	elems = elems[:0]
+	s.Traversal(func(e string) bool {
+		elems = append(elems, e)
+		return len(elems) < 2
+	})
+	assert.Equal(t, []string{"c", "b"}, elems)
+
+	iter := s.Last()
+	assert.Equal(t, "a", iter.Value())
+	iter.Prev()
+	assert.True(t, iter.Equal(s.Find("b")))
+
+	s.Reserve(100)
+	assert.GreaterOrEqual(t, s.Capacity(), 100)
+	assert.Equal(t, 3, s.Stats().Size)
+	s.Clear()
+	assert.True(t, s.Empty())
+	assert.Equal(t, 0.0, s.LoadFactor())
+}
+
+func BenchmarkRobinHoodMapInsert(b *testing.B) {
+	m := NewRobinHoodMap[int, int](hamt.OrderedHasher[int])
+	for i := 0; i < b.N; i++ {
+		m.Insert(i, i)
+	}
+}
+
+func BenchmarkRobinHoodMapGet(b *testing.B) {
+	m := NewRobinHoodMap[int, int](hamt.OrderedHasher[int])
+	for i := 0; i < benchKeys; i++ {
+		m.Insert(i, i)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Get(i & (benchKeys - 1))
+	}
+}
@@ -0,0 +1,156 @@
+package heap
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+var _ Heap[int] = (*DaryHeap[int])(nil)
+
+type daryNode[T any] struct {
+	value T
+	index int // position in the heap, -1 if the node has been popped
+}
+
+// Value returns the value of the node
+func (n *daryNode[T]) Value() T {
+	return n.value
+}
+
+// DaryHeap is an implicit heap in which each node has d children, a 4-ary heap is shallower
+// and more cache friendly than a binary heap
+type DaryHeap[T any] struct {
+	nodes []*daryNode[T]
+	d     int
+	cmp   comparator.Comparator[T]
+}
+
+// NewDary creates a DaryHeap with d children per node, d less than 2 is treated as 2
+func NewDary[T any](d int, cmp comparator.Comparator[T]) *DaryHeap[T] {
+	if d < 2 {
+		d = 2
+	}
+	return &DaryHeap[T]{d: d, cmp: cmp}
+}
+
+// Push pushes a value onto the heap and returns its handle
+func (h *DaryHeap[T]) Push(value T) Handle[T] {
+	n := &daryNode[T]{value: value, index: len(h.nodes)}
+	h.nodes = append(h.nodes, n)
+	h.up(n.index)
+	return n
+}
+
+// Pop removes and returns the minimum value from the heap
+func (h *DaryHeap[T]) Pop() T {
+	if len(h.nodes) == 0 {
+		panic("heap is empty")
+	}
+	top := h.nodes[0]
+	last := len(h.nodes) - 1
+	h.swap(0, last)
+	h.nodes[last] = nil
+	h.nodes = h.nodes[:last]
+	h.down(0)
+	top.index = -1
+	return top.value
+}
+
+// Peek returns the minimum value in the heap
+func (h *DaryHeap[T]) Peek() T {
+	if len(h.nodes) == 0 {
+		panic("heap is empty")
+	}
+	return h.nodes[0].value
+}
+
+// Len returns the amount of values in the heap
+func (h *DaryHeap[T]) Len() int {
+	return len(h.nodes)
+}
+
+// Meld moves all values of other into the heap in O(n+m) time
+func (h *DaryHeap[T]) Meld(other Heap[T]) {
+	o, ok := other.(*DaryHeap[T])
+	if !ok {
+		drain[T](h, other)
+		return
+	}
+	if o == h {
+		return
+	}
+	for _, n := range o.nodes {
+		n.index = len(h.nodes)
+		h.nodes = append(h.nodes, n)
+	}
+	o.nodes = nil
+	for i := (len(h.nodes) - 2) / h.d; i >= 0; i-- {
+		h.down(i)
+	}
+}
+
+// DecreaseKey decreases the value of the handle
+func (h *DaryHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	n, ok := handle.(*daryNode[T])
+	if !ok || n.index < 0 || n.index >= len(h.nodes) || h.nodes[n.index] != n {
+		return ErrorInvalidHandle
+	}
+	if h.cmp(value, n.value) > 0 {
+		return ErrorKeyIncreased
+	}
+	n.value = value
+	h.up(n.index)
+	return nil
+}
+
+// Traversal traversals values in the heap in the internal array order
+func (h *DaryHeap[T]) Traversal(visitor visitor.Visitor[T]) {
+	for _, n := range h.nodes {
+		if !visitor(n.value) {
+			return
+		}
+	}
+}
+
+func (h *DaryHeap[T]) less(i, j int) bool {
+	return h.cmp(h.nodes[i].value, h.nodes[j].value) < 0
+}
+
+func (h *DaryHeap[T]) swap(i, j int) {
+	h.nodes[i], h.nodes[j] = h.nodes[j], h.nodes[i]
+	h.nodes[i].index = i
+	h.nodes[j].index = j
+}
+
+func (h *DaryHeap[T]) up(j int) {
+	for j > 0 {
+		i := (j - 1) / h.d // parent
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		j = i
+	}
+}
+
+func (h *DaryHeap[T]) down(i int) {
+	n := len(h.nodes)
+	for {
+		first := h.d*i + 1
+		if first >= n || first < 0 { // first < 0 after int overflow
+			break
+		}
+		// find the minimum child
+		j := first
+		for c := first + 1; c < first+h.d && c < n; c++ {
+			if h.less(c, j) {
+				j = c
+			}
+		}
+		if !h.less(j, i) {
+			break
+		}
+		h.swap(i, j)
+		i = j
+	}
+}
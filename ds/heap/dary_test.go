@@ -0,0 +1,88 @@
+package heap
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDaryHeap(t *testing.T) {
+	h := NewDary[int](4, comparator.IntComparator)
+	assert.Panics(t, func() { h.Pop() })
+	assert.Panics(t, func() { h.Peek() })
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		h.Push(v)
+	}
+	assert.Equal(t, 6, h.Len())
+	assert.Equal(t, 1, h.Peek())
+
+	h8 := h.Push(8)
+	assert.Equal(t, 8, h8.Value())
+	assert.Equal(t, ErrorKeyIncreased, h.DecreaseKey(h8, 10))
+	assert.Nil(t, h.DecreaseKey(h8, 0))
+	assert.Equal(t, 0, h8.Value())
+	assert.Equal(t, 0, h.Peek())
+	assert.Equal(t, 0, h.Pop())
+	assert.Equal(t, ErrorInvalidHandle, h.DecreaseKey(h8, -1))
+
+	sum := 0
+	h.Traversal(func(v int) bool {
+		sum += v
+		return true
+	})
+	assert.Equal(t, 28, sum)
+
+	result := make([]int, 0)
+	for h.Len() > 0 {
+		result = append(result, h.Pop())
+	}
+	assert.Equal(t, []int{1, 2, 3, 5, 8, 9}, result)
+}
+
+func TestBinaryHeapRandom(t *testing.T) {
+	testRandomOps(t, NewDary[int](2, comparator.IntComparator))
+}
+
+func TestDaryHeapRandom(t *testing.T) {
+	testRandomOps(t, NewDary[int](4, comparator.IntComparator))
+}
+
+func TestDaryHeapMeld(t *testing.T) {
+	a := NewDary[int](4, comparator.IntComparator)
+	b := NewDary[int](4, comparator.IntComparator)
+	for i := 0; i < 100; i += 2 {
+		a.Push(i)
+		b.Push(i + 1)
+	}
+	handle := b.Push(200)
+	a.Meld(b)
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, 101, a.Len())
+	assert.Nil(t, a.DecreaseKey(handle, -1))
+	assert.Equal(t, ErrorInvalidHandle, b.DecreaseKey(handle, -2))
+	a.Meld(a)
+	assert.Equal(t, 101, a.Len())
+
+	// meld a heap of another type
+	c := NewPairing[int](comparator.IntComparator)
+	c.Push(1000)
+	a.Meld(c)
+	assert.Equal(t, 0, c.Len())
+
+	assert.Equal(t, -1, a.Pop())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i, a.Pop())
+	}
+	assert.Equal(t, 1000, a.Pop())
+	assert.Equal(t, 0, a.Len())
+}
+
+func BenchmarkBinaryHeap(b *testing.B) {
+	benchmarkHeap(b, func() Heap[int] { return NewDary[int](2, comparator.IntComparator) })
+}
+
+func Benchmark4aryHeap(b *testing.B) {
+	benchmarkHeap(b, func() Heap[int] { return NewDary[int](4, comparator.IntComparator) })
+}
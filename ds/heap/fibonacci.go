@@ -0,0 +1,269 @@
+package heap
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+var _ Heap[int] = (*FibonacciHeap[int])(nil)
+
+type fibonacciNode[T any] struct {
+	value  T
+	parent *fibonacciNode[T]
+	child  *fibonacciNode[T]
+	left   *fibonacciNode[T] // siblings are in a circular doubly linked list
+	right  *fibonacciNode[T]
+	degree int
+	mark   bool
+	popped bool
+	owner  *owner
+}
+
+// Value returns the value of the node
+func (n *fibonacciNode[T]) Value() T {
+	return n.value
+}
+
+// FibonacciHeap is a collection of heap-ordered trees, Push, Meld and DecreaseKey take amortized O(1) time,
+// and Pop takes amortized O(log n) time
+type FibonacciHeap[T any] struct {
+	min   *fibonacciNode[T]
+	size  int
+	cmp   comparator.Comparator[T]
+	owner *owner
+
+	// buffers reused by Pop
+	nodes   []*fibonacciNode[T]
+	degrees []*fibonacciNode[T]
+}
+
+// NewFibonacci creates a FibonacciHeap
+func NewFibonacci[T any](cmp comparator.Comparator[T]) *FibonacciHeap[T] {
+	return &FibonacciHeap[T]{cmp: cmp, owner: &owner{}}
+}
+
+// Push pushes a value onto the heap and returns its handle
+func (h *FibonacciHeap[T]) Push(value T) Handle[T] {
+	n := &fibonacciNode[T]{value: value, owner: h.owner}
+	h.addRoot(n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the minimum value from the heap
+func (h *FibonacciHeap[T]) Pop() T {
+	z := h.min
+	if z == nil {
+		panic("heap is empty")
+	}
+	// move the children of z to the root list
+	h.nodes = appendSiblings(h.nodes[:0], z.child)
+	for _, c := range h.nodes {
+		c.parent = nil
+		c.mark = false
+		h.addRoot(c)
+	}
+	z.child = nil
+
+	// remove z from the root list
+	z.left.right = z.right
+	z.right.left = z.left
+	if z.right == z {
+		h.min = nil
+	} else {
+		h.min = z.right
+		h.consolidate()
+	}
+	h.size--
+	z.left, z.right = nil, nil
+	z.popped = true
+	return z.value
+}
+
+// Peek returns the minimum value in the heap
+func (h *FibonacciHeap[T]) Peek() T {
+	if h.min == nil {
+		panic("heap is empty")
+	}
+	return h.min.value
+}
+
+// Len returns the amount of values in the heap
+func (h *FibonacciHeap[T]) Len() int {
+	return h.size
+}
+
+// Meld moves all values of other into the heap in O(1) time if other is a FibonacciHeap
+func (h *FibonacciHeap[T]) Meld(other Heap[T]) {
+	o, ok := other.(*FibonacciHeap[T])
+	if !ok {
+		drain[T](h, other)
+		return
+	}
+	if o == h || o.min == nil {
+		return
+	}
+	if h.min == nil {
+		h.min = o.min
+	} else {
+		// concatenate the two root lists
+		a, b := h.min.right, o.min.left
+		h.min.right = o.min
+		o.min.left = h.min
+		a.left = b
+		b.right = a
+		if h.less(o.min, h.min) {
+			h.min = o.min
+		}
+	}
+	h.size += o.size
+	o.min = nil
+	o.size = 0
+	o.owner.parent = h.owner
+	o.owner = &owner{}
+}
+
+// DecreaseKey decreases the value of the handle
+func (h *FibonacciHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	n, ok := handle.(*fibonacciNode[T])
+	if !ok || n.popped || n.left == nil || n.owner.find() != h.owner {
+		return ErrorInvalidHandle
+	}
+	if h.cmp(value, n.value) > 0 {
+		return ErrorKeyIncreased
+	}
+	n.value = value
+	if p := n.parent; p != nil && h.less(n, p) {
+		h.cut(n, p)
+		h.cascadingCut(p)
+	}
+	if h.less(n, h.min) {
+		h.min = n
+	}
+	return nil
+}
+
+// Traversal traversals values in the heap in pre-order
+func (h *FibonacciHeap[T]) Traversal(visitor visitor.Visitor[T]) {
+	h.traversal(h.min, visitor)
+}
+
+func (h *FibonacciHeap[T]) traversal(first *fibonacciNode[T], visitor visitor.Visitor[T]) bool {
+	for _, n := range appendSiblings(nil, first) {
+		if !visitor(n.value) || !h.traversal(n.child, visitor) {
+			return false
+		}
+	}
+	return true
+}
+
+func (h *FibonacciHeap[T]) less(a, b *fibonacciNode[T]) bool {
+	return h.cmp(a.value, b.value) < 0
+}
+
+// addRoot adds n to the root list and updates the minimum
+func (h *FibonacciHeap[T]) addRoot(n *fibonacciNode[T]) {
+	if h.min == nil {
+		n.left, n.right = n, n
+		h.min = n
+		return
+	}
+	n.left = h.min
+	n.right = h.min.right
+	h.min.right.left = n
+	h.min.right = n
+	if h.less(n, h.min) {
+		h.min = n
+	}
+}
+
+// consolidate links the roots with the same degree until every root has a distinct degree
+func (h *FibonacciHeap[T]) consolidate() {
+	degrees := h.degrees[:0]
+	h.nodes = appendSiblings(h.nodes[:0], h.min)
+	for _, x := range h.nodes {
+		d := x.degree
+		for d < len(degrees) && degrees[d] != nil {
+			y := degrees[d]
+			if h.less(y, x) {
+				x, y = y, x
+			}
+			h.link(y, x)
+			degrees[d] = nil
+			d++
+		}
+		for d >= len(degrees) {
+			degrees = append(degrees, nil)
+		}
+		degrees[d] = x
+	}
+
+	// rebuild the root list
+	h.min = nil
+	for i, x := range degrees {
+		if x != nil {
+			h.addRoot(x)
+			degrees[i] = nil
+		}
+	}
+	h.degrees = degrees
+}
+
+// link makes the root y a child of the root x
+func (h *FibonacciHeap[T]) link(y, x *fibonacciNode[T]) {
+	y.parent = x
+	y.mark = false
+	if x.child == nil {
+		y.left, y.right = y, y
+		x.child = y
+	} else {
+		y.left = x.child
+		y.right = x.child.right
+		x.child.right.left = y
+		x.child.right = y
+	}
+	x.degree++
+}
+
+// cut moves x from the child list of its parent y to the root list
+func (h *FibonacciHeap[T]) cut(x, y *fibonacciNode[T]) {
+	if x.right == x {
+		y.child = nil
+	} else {
+		x.left.right = x.right
+		x.right.left = x.left
+		if y.child == x {
+			y.child = x.right
+		}
+	}
+	y.degree--
+	x.parent = nil
+	x.mark = false
+	h.addRoot(x)
+}
+
+func (h *FibonacciHeap[T]) cascadingCut(y *fibonacciNode[T]) {
+	for z := y.parent; z != nil; z = y.parent {
+		if !y.mark {
+			y.mark = true
+			return
+		}
+		h.cut(y, z)
+		y = z
+	}
+}
+
+// appendSiblings appends the nodes in the circular list starting from first to nodes
+func appendSiblings[T any](nodes []*fibonacciNode[T], first *fibonacciNode[T]) []*fibonacciNode[T] {
+	if first == nil {
+		return nodes
+	}
+	for n := first; ; {
+		nodes = append(nodes, n)
+		n = n.right
+		if n == first {
+			break
+		}
+	}
+	return nodes
+}
@@ -0,0 +1,108 @@
+package heap
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFibonacciHeap(t *testing.T) {
+	h := NewFibonacci[int](comparator.IntComparator)
+	assert.Panics(t, func() { h.Pop() })
+	assert.Panics(t, func() { h.Peek() })
+
+	for _, v := range []int{5, 3, 8, 1, 9, 2} {
+		h.Push(v)
+	}
+	assert.Equal(t, 6, h.Len())
+	assert.Equal(t, 1, h.Peek())
+
+	h8 := h.Push(8)
+	assert.Equal(t, 8, h8.Value())
+	assert.Equal(t, ErrorKeyIncreased, h.DecreaseKey(h8, 10))
+	assert.Nil(t, h.DecreaseKey(h8, 0))
+	assert.Equal(t, 0, h8.Value())
+	assert.Equal(t, 0, h.Peek())
+	assert.Equal(t, 0, h.Pop())
+	assert.Equal(t, ErrorInvalidHandle, h.DecreaseKey(h8, -1))
+
+	sum := 0
+	h.Traversal(func(v int) bool {
+		sum += v
+		return true
+	})
+	assert.Equal(t, 28, sum)
+
+	result := make([]int, 0)
+	for h.Len() > 0 {
+		result = append(result, h.Pop())
+	}
+	assert.Equal(t, []int{1, 2, 3, 5, 8, 9}, result)
+}
+
+func TestFibonacciHeapRandom(t *testing.T) {
+	testRandomOps(t, NewFibonacci[int](comparator.IntComparator))
+}
+
+func TestFibonacciHeapMeld(t *testing.T) {
+	a := NewFibonacci[int](comparator.IntComparator)
+	b := NewFibonacci[int](comparator.IntComparator)
+	for i := 0; i < 100; i += 2 {
+		a.Push(i)
+		b.Push(i + 1)
+	}
+	handle := b.Push(200)
+	a.Meld(b)
+	assert.Equal(t, 0, b.Len())
+	assert.Equal(t, 101, a.Len())
+	assert.Nil(t, a.DecreaseKey(handle, -1))
+	assert.Equal(t, ErrorInvalidHandle, b.DecreaseKey(handle, -2))
+	a.Meld(a)
+	assert.Equal(t, 101, a.Len())
+
+	// meld a heap of another type
+	c := NewPairing[int](comparator.IntComparator)
+	c.Push(1000)
+	a.Meld(c)
+	assert.Equal(t, 0, c.Len())
+
+	assert.Equal(t, -1, a.Pop())
+	for i := 0; i < 100; i++ {
+		assert.Equal(t, i, a.Pop())
+	}
+	assert.Equal(t, 1000, a.Pop())
+	assert.Equal(t, 0, a.Len())
+}
+
+func TestFibonacciHeapForeignHandle(t *testing.T) {
+	a := NewFibonacci[int](comparator.IntComparator)
+	b := NewFibonacci[int](comparator.IntComparator)
+	a.Push(1)
+	a.Push(2)
+	b.Push(3)
+	handle := b.Push(4)
+	assert.Equal(t, ErrorInvalidHandle, a.DecreaseKey(handle, 0))
+	assert.Equal(t, 2, a.Len())
+	assert.Equal(t, 2, b.Len())
+	assert.Equal(t, 1, a.Peek())
+	assert.Equal(t, 3, b.Peek())
+
+	// the handle belongs to a after b is melded into a, and not to b any more
+	a.Meld(b)
+	b.Push(5)
+	assert.Equal(t, ErrorInvalidHandle, b.DecreaseKey(handle, 0))
+	assert.Nil(t, a.DecreaseKey(handle, 0))
+	handle = a.Push(7)
+	assert.Equal(t, 0, a.Pop())
+	// handles follow the heaps melded more than once
+	c := NewFibonacci[int](comparator.IntComparator)
+	c.Meld(a)
+	assert.Equal(t, ErrorInvalidHandle, a.DecreaseKey(handle, -1))
+	assert.Nil(t, c.DecreaseKey(handle, -1))
+	assert.Equal(t, -1, c.Pop())
+}
+
+func BenchmarkFibonacciHeap(b *testing.B) {
+	benchmarkHeap(b, func() Heap[int] { return NewFibonacci[int](comparator.IntComparator) })
+}
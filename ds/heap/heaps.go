@@ -0,0 +1,71 @@
+package heap
+
+import (
+	"errors"
+
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+var (
+	ErrorInvalidHandle = errors.New("invalid handle")
+	ErrorKeyIncreased  = errors.New("new value is greater than the current value")
+)
+
+// Handle refers to an element in a Heap, it is returned by Push and used by DecreaseKey
+type Handle[T any] interface {
+	// Value returns the element's value
+	Value() T
+}
+
+// Heap is a min-heap ordered by a comparator, it is implemented by DaryHeap, PairingHeap and FibonacciHeap
+type Heap[T any] interface {
+	// Push pushes a value onto the heap and returns its handle
+	Push(value T) Handle[T]
+
+	// Pop removes and returns the minimum value from the heap, it panics if the heap is empty
+	Pop() T
+
+	// Peek returns the minimum value in the heap, it panics if the heap is empty
+	Peek() T
+
+	// Len returns the amount of values in the heap
+	Len() int
+
+	// Meld moves all values of other into the heap, other becomes empty.
+	// Handles of other are still valid in the heap if other is of the same type,
+	// otherwise values are popped from other and pushed into the heap one by one
+	Meld(other Heap[T])
+
+	// DecreaseKey decreases the value of the handle, it returns ErrorKeyIncreased if the new value is greater than
+	// the current value, and ErrorInvalidHandle if the handle has been popped or doesn't belong to this heap.
+	// A handle belongs to the heap which it was pushed into, or the heap which that heap has been melded into
+	DecreaseKey(handle Handle[T], value T) error
+
+	// Traversal traversals values in the heap in an unspecified order, it will stop if the visitor returns false
+	Traversal(visitor visitor.Visitor[T])
+}
+
+// drain pops all values from other and pushes them into h
+func drain[T any](h, other Heap[T]) {
+	for other.Len() > 0 {
+		h.Push(other.Pop())
+	}
+}
+
+// owner identifies the heap which a node belongs to. Meld links the owner of the melded heap to the owner
+// of the heap it is melded into, so the nodes of both heaps are moved in O(1) time
+type owner struct {
+	parent *owner
+}
+
+// find returns the current owner of o, and compresses the path to it
+func (o *owner) find() *owner {
+	root := o
+	for root.parent != nil {
+		root = root.parent
+	}
+	for o != root {
+		o, o.parent = o.parent, root
+	}
+	return root
+}
@@ -0,0 +1,71 @@
+package heap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testRandomOps applies random Push, Pop and DecreaseKey to the empty heap h and checks the values popped
+func testRandomOps(t *testing.T, h Heap[int]) {
+	// the low 12 bits of a value are the id of its handle, so that values are unique
+	handles := make([]Handle[int], 0)
+	values := make(map[int]int)
+	for i := 0; i < 4000; i++ {
+		switch rand.Intn(4) {
+		case 0, 1:
+			id := len(handles)
+			v := rand.Intn(100000)<<12 | id
+			handles = append(handles, h.Push(v))
+			values[id] = v
+		case 2:
+			if h.Len() > 0 {
+				v := h.Pop()
+				assert.Equal(t, v, values[v&0xfff])
+				delete(values, v&0xfff)
+			}
+		default:
+			if len(handles) > 0 {
+				id := rand.Intn(len(handles))
+				if v, ok := values[id]; ok {
+					v -= rand.Intn(1000) << 12
+					assert.Nil(t, h.DecreaseKey(handles[id], v))
+					values[id] = v
+				} else {
+					assert.Equal(t, ErrorInvalidHandle, h.DecreaseKey(handles[id], 0))
+				}
+			}
+		}
+	}
+
+	expected := make([]int, 0, len(values))
+	for _, v := range values {
+		expected = append(expected, v)
+	}
+	sort.Ints(expected)
+	assert.Equal(t, len(expected), h.Len())
+	for _, v := range expected {
+		assert.Equal(t, v, h.Pop())
+	}
+}
+
+func benchmarkHeap(b *testing.B, newHeap func() Heap[int]) {
+	const n = 10000
+	values := rand.Perm(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h := newHeap()
+		handles := make([]Handle[int], n)
+		for j, v := range values {
+			handles[j] = h.Push(v + n)
+		}
+		for j := 0; j < n; j += 2 {
+			h.DecreaseKey(handles[j], handles[j].Value()-n)
+		}
+		for h.Len() > 0 {
+			h.Pop()
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package heap
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+var _ Heap[int] = (*PairingHeap[int])(nil)
+
+type pairingNode[T any] struct {
+	value   T
+	child   *pairingNode[T] // leftmost child
+	sibling *pairingNode[T] // right sibling
+	prev    *pairingNode[T] // parent if the node is the leftmost child, otherwise the left sibling
+	popped  bool
+	owner   *owner
+}
+
+// Value returns the value of the node
+func (n *pairingNode[T]) Value() T {
+	return n.value
+}
+
+// PairingHeap is a heap-ordered multi-way tree, Push, Meld and DecreaseKey take O(1) time (amortized O(log n) for
+// DecreaseKey in theory, but very fast in practice), and Pop takes amortized O(log n) time
+type PairingHeap[T any] struct {
+	root  *pairingNode[T]
+	size  int
+	cmp   comparator.Comparator[T]
+	owner *owner
+}
+
+// NewPairing creates a PairingHeap
+func NewPairing[T any](cmp comparator.Comparator[T]) *PairingHeap[T] {
+	return &PairingHeap[T]{cmp: cmp, owner: &owner{}}
+}
+
+// Push pushes a value onto the heap and returns its handle
+func (h *PairingHeap[T]) Push(value T) Handle[T] {
+	n := &pairingNode[T]{value: value, owner: h.owner}
+	h.root = h.meld(h.root, n)
+	h.size++
+	return n
+}
+
+// Pop removes and returns the minimum value from the heap
+func (h *PairingHeap[T]) Pop() T {
+	if h.root == nil {
+		panic("heap is empty")
+	}
+	top := h.root
+	h.root = h.mergePairs(top.child)
+	h.size--
+	top.child = nil
+	top.popped = true
+	return top.value
+}
+
+// Peek returns the minimum value in the heap
+func (h *PairingHeap[T]) Peek() T {
+	if h.root == nil {
+		panic("heap is empty")
+	}
+	return h.root.value
+}
+
+// Len returns the amount of values in the heap
+func (h *PairingHeap[T]) Len() int {
+	return h.size
+}
+
+// Meld moves all values of other into the heap in O(1) time if other is a PairingHeap
+func (h *PairingHeap[T]) Meld(other Heap[T]) {
+	o, ok := other.(*PairingHeap[T])
+	if !ok {
+		drain[T](h, other)
+		return
+	}
+	if o == h {
+		return
+	}
+	h.root = h.meld(h.root, o.root)
+	h.size += o.size
+	o.root = nil
+	o.size = 0
+	o.owner.parent = h.owner
+	o.owner = &owner{}
+}
+
+// DecreaseKey decreases the value of the handle
+func (h *PairingHeap[T]) DecreaseKey(handle Handle[T], value T) error {
+	n, ok := handle.(*pairingNode[T])
+	if !ok || n.popped || (n.prev == nil && n != h.root) || n.owner.find() != h.owner {
+		return ErrorInvalidHandle
+	}
+	if h.cmp(value, n.value) > 0 {
+		return ErrorKeyIncreased
+	}
+	n.value = value
+	if n == h.root {
+		return nil
+	}
+	// cut the subtree of n and meld it with the root
+	if n.prev.child == n {
+		n.prev.child = n.sibling
+	} else {
+		n.prev.sibling = n.sibling
+	}
+	if n.sibling != nil {
+		n.sibling.prev = n.prev
+	}
+	n.prev = nil
+	n.sibling = nil
+	h.root = h.meld(h.root, n)
+	return nil
+}
+
+// Traversal traversals values in the heap in pre-order
+func (h *PairingHeap[T]) Traversal(visitor visitor.Visitor[T]) {
+	stack := make([]*pairingNode[T], 0)
+	if h.root != nil {
+		stack = append(stack, h.root)
+	}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if !visitor(n.value) {
+			return
+		}
+		for c := n.child; c != nil; c = c.sibling {
+			stack = append(stack, c)
+		}
+	}
+}
+
+// meld links two trees and returns the new root, the roots must have no siblings
+func (h *PairingHeap[T]) meld(a, b *pairingNode[T]) *pairingNode[T] {
+	if a == nil {
+		return b
+	}
+	if b == nil {
+		return a
+	}
+	if h.cmp(b.value, a.value) < 0 {
+		a, b = b, a
+	}
+	// b becomes the leftmost child of a
+	b.prev = a
+	b.sibling = a.child
+	if a.child != nil {
+		a.child.prev = b
+	}
+	a.child = b
+	return a
+}
+
+// mergePairs merges the sibling list starting from first in two passes, and returns the new root
+func (h *PairingHeap[T]) mergePairs(first *pairingNode[T]) *pairingNode[T] {
+	// first pass: meld pairs from left to right
+	pairs := make([]*pairingNode[T], 0)
+	for a := first; a != nil; {
+		b := a.sibling
+		var next *pairingNode[T]
+		if b != nil {
+			next = b.sibling
+			b.prev, b.sibling = nil, nil
+		}
+		a.prev, a.sibling = nil, nil
+		pairs = append(pairs, h.meld(a, b))
+		a = next
+	}
+	// second pass: meld the pairs from right to left
+	var root *pairingNode[T]
+	for i := len(pairs) - 1; i >= 0; i-- {
+		root = h.meld(pairs[i], root)
+	}
+	return root
+}
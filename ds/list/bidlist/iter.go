@@ -0,0 +1,42 @@
+//go:build go1.23
+
+package bidlist
+
+import "iter"
+
+// All returns an iterator over index-value pairs in the list, from front to back
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for node := l.FrontNode(); node != nil; node = node.Next() {
+			if !yield(i, node.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs in the list, from back to front
+func (l *List[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := l.len - 1
+		for node := l.BackNode(); node != nil; node = node.Prev() {
+			if !yield(i, node.Value) {
+				return
+			}
+			i--
+		}
+	}
+}
+
+// Values returns an iterator over values in the list, from front to back
+func (l *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l.FrontNode(); node != nil; node = node.Next() {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
@@ -125,8 +125,19 @@ func (l *List[T]) pushBack(v T) *Node[T] {
 
 // PushFront inserts a new node n with value v at the front of the list.
 func (l *List[T]) PushFront(v T) {
+	l.PushFrontNode(v)
+}
+
+// PushBackNode inserts a new node n with value v at the back of the list and returns n.
+func (l *List[T]) PushBackNode(v T) *Node[T] {
+	return l.pushBack(v)
+}
+
+// PushFrontNode inserts a new node n with value v at the front of the list and returns n.
+func (l *List[T]) PushFrontNode(v T) *Node[T] {
 	n := l.pushBack(v)
 	l.head = n
+	return n
 }
 
 // InsertAfter inserts a new node n with value v immediately after mark and returns n.
@@ -117,6 +117,20 @@ func TestPushBackList(t *testing.T) {
 	assert.Equal(t, "[7 8 5 7 8 5 1 2 3 1 2 3]", list.String())
 }
 
+func TestPushNode(t *testing.T) {
+	list := New[int]()
+	n1 := list.PushFrontNode(1)
+	n2 := list.PushBackNode(2)
+	n0 := list.PushFrontNode(0)
+	assert.Equal(t, "[0 1 2]", list.String())
+	assert.Equal(t, n0, list.FrontNode())
+	assert.Equal(t, n2, list.BackNode())
+	list.MoveToFront(n1)
+	assert.Equal(t, "[1 0 2]", list.String())
+	assert.Equal(t, 2, list.Remove(n2))
+	assert.Equal(t, "[1 0]", list.String())
+}
+
 func TestListIterator(t *testing.T) {
 	list := New[int]()
 	for i := 1; i <= 5; i++ {
@@ -0,0 +1,29 @@
+//go:build go1.23
+
+package simplelist
+
+import "iter"
+
+// All returns an iterator over index-value pairs in the list, from front to back
+func (l *List[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for node := l.head; node != nil; node = node.Next() {
+			if !yield(i, node.Value) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Values returns an iterator over values in the list, from front to back
+func (l *List[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for node := l.head; node != nil; node = node.Next() {
+			if !yield(node.Value) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,33 @@
+//go:build go1.23
+
+package simplelist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListIterSeq(t *testing.T) {
+	l := New[int]()
+	for i := 0; i < 5; i++ {
+		l.PushBack(i * 10)
+	}
+
+	n := 0
+	for i, val := range l.All() {
+		assert.Equal(t, n, i)
+		assert.Equal(t, i*10, val)
+		n++
+	}
+	assert.Equal(t, 5, n)
+
+	values := make([]int, 0)
+	for val := range l.Values() {
+		if val > 20 {
+			break
+		}
+		values = append(values, val)
+	}
+	assert.Equal(t, []int{0, 10, 20}, values)
+}
@@ -0,0 +1,127 @@
+package treemap
+
+import (
+	"encoding/json"
+
+	"github.com/liyue201/gostl/utils/codec"
+)
+
+// jsonEntry is the JSON representation of a key-value pair
+type jsonEntry[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// MarshalBinary encodes the map with its key and value codecs, it implements encoding.BinaryMarshaler
+func (m *Map[K, V]) MarshalBinary() ([]byte, error) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	if err := codec.Check(m.keyCodec, m.valueCodec); err != nil {
+		return nil, err
+	}
+	var err error
+	buf := codec.AppendUvarint(nil, uint64(m.tree.Size()))
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		if buf, err = m.keyCodec.Encode(buf, node.Key()); err != nil {
+			return nil, err
+		}
+		if buf, err = m.valueCodec.Encode(buf, node.Value()); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the map with the data returned by MarshalBinary,
+// it implements encoding.BinaryUnmarshaler. Note that the map must be created by New with a comparator
+func (m *Map[K, V]) UnmarshalBinary(data []byte) error {
+	if err := codec.Check(m.keyCodec, m.valueCodec); err != nil {
+		return err
+	}
+	n, data, err := codec.ReadLen(data)
+	if err != nil {
+		return err
+	}
+	keys := make([]K, n)
+	values := make([]V, n)
+	for i := 0; i < n; i++ {
+		if keys[i], data, err = m.keyCodec.Decode(data); err != nil {
+			return err
+		}
+		if values[i], data, err = m.valueCodec.Decode(data); err != nil {
+			return err
+		}
+	}
+	if len(data) != 0 {
+		return codec.ErrorCorruptData
+	}
+
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.build(keys, values)
+	return nil
+}
+
+// MarshalJSON encodes the map into a JSON array of key-value objects, it implements json.Marshaler
+func (m *Map[K, V]) MarshalJSON() ([]byte, error) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	entries := make([]jsonEntry[K, V], 0, m.tree.Size())
+	for node := m.tree.First(); node != nil; node = node.Next() {
+		entries = append(entries, jsonEntry[K, V]{Key: node.Key(), Value: node.Value()})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the content of the map with the data returned by MarshalJSON, it implements json.Unmarshaler.
+// Note that the map must be created by New with a comparator
+func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	keys := make([]K, len(entries))
+	values := make([]V, len(entries))
+	for i := range entries {
+		keys[i] = entries[i].Key
+		values[i] = entries[i].Value
+	}
+
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	m.build(keys, values)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, it is the same as MarshalBinary
+func (m *Map[K, V]) GobEncode() ([]byte, error) {
+	return m.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, it is the same as UnmarshalBinary
+func (m *Map[K, V]) GobDecode(data []byte) error {
+	return m.UnmarshalBinary(data)
+}
+
+// build replaces the content of the map with the keys and values, it takes O(n) time if the keys are
+// strictly increasing wrt the map's comparator, otherwise the key-value pairs are inserted one by one
+func (m *Map[K, V]) build(keys []K, values []V) {
+	for i := 1; i < len(keys); i++ {
+		if m.tree.Compare(keys[i-1], keys[i]) >= 0 {
+			m.tree.Clear()
+			for j := range keys {
+				if node := m.tree.FindNode(keys[j]); node != nil {
+					node.SetValue(values[j])
+				} else {
+					m.tree.Insert(keys[j], values[j])
+				}
+			}
+			return
+		}
+	}
+	m.tree.BuildFromSorted(keys, values)
+}
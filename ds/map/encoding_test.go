@@ -0,0 +1,79 @@
+package treemap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapBinary(t *testing.T) {
+	m := New[int, string](comparator.IntComparator, WithKeyCodec[int](codec.Integer[int]{}), WithValueCodec[string](codec.String[string]{}))
+	for i := 0; i < 100; i++ {
+		m.Insert(i, string(rune('a'+i%26)))
+	}
+	data, err := m.MarshalBinary()
+	assert.Nil(t, err)
+
+	m2 := New[int, string](comparator.IntComparator, WithKeyCodec[int](codec.Integer[int]{}), WithValueCodec[string](codec.String[string]{}))
+	m2.Insert(1000, "x")
+	assert.Nil(t, m2.UnmarshalBinary(data))
+	assert.Equal(t, 100, m2.Size())
+	assert.False(t, m2.Contains(1000))
+	for i := 0; i < 100; i++ {
+		v, _ := m2.Get(i)
+		assert.Equal(t, string(rune('a'+i%26)), v)
+	}
+
+	// decode with a reversed comparator
+	m3 := New[int, string](comparator.Reverse(comparator.IntComparator), WithKeyCodec[int](codec.Integer[int]{}), WithValueCodec[string](codec.String[string]{}))
+	assert.Nil(t, m3.UnmarshalBinary(data))
+	assert.Equal(t, 99, m3.First().Key())
+	assert.Equal(t, 100, m3.Size())
+
+	assert.Equal(t, codec.ErrorCorruptData, m2.UnmarshalBinary(data[:len(data)-1]))
+	assert.Equal(t, codec.ErrorCorruptData, m2.UnmarshalBinary(append(data, 0)))
+}
+
+func TestMapJSONAndGob(t *testing.T) {
+	m := New[string, int](comparator.StringComparator)
+	m.Insert("b", 2)
+	m.Insert("a", 1)
+	m.Insert("c", 3)
+
+	data, err := json.Marshal(m)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"key":"a","value":1},{"key":"b","value":2},{"key":"c","value":3}]`, string(data))
+
+	m2 := New[string, int](comparator.StringComparator)
+	assert.Nil(t, json.Unmarshal(data, m2))
+	assert.Equal(t, 3, m2.Size())
+	v, _ := m2.Get("b")
+	assert.Equal(t, 2, v)
+
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(m))
+	m3 := New[string, int](comparator.StringComparator)
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(m3))
+	assert.Equal(t, 3, m3.Size())
+	v, _ = m3.Get("c")
+	assert.Equal(t, 3, v)
+}
+
+func TestMapWrongCodec(t *testing.T) {
+	m := New[int, string](comparator.IntComparator, WithKeyCodec[string](codec.String[string]{}))
+	_, err := m.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, m.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+
+	m = New[int, string](comparator.IntComparator, WithValueCodec[int](codec.Integer[int]{}))
+	m.Insert(1, "a")
+	_, err = m.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, m.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+	assert.Equal(t, 1, m.Size())
+}
@@ -0,0 +1,117 @@
+//go:build go1.23
+
+package treemap
+
+import "iter"
+
+// All returns an iterator over key-value pairs in the map, in ascending order of keys
+func (m *Map[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key(), node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the map, in descending order of keys
+func (m *Map[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.Last(); node != nil; node = node.Prev() {
+			if !yield(node.Key(), node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over keys in the map, in ascending order
+func (m *Map[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the map, in ascending order of keys
+func (m *Map[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		m.locker.RLock()
+		defer m.locker.RUnlock()
+
+		for node := m.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over key-value pairs in the MultiMap, in ascending order of keys
+func (mm *MultiMap[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mm.locker.RLock()
+		defer mm.locker.RUnlock()
+
+		for node := mm.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key(), node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over key-value pairs in the MultiMap, in descending order of keys
+func (mm *MultiMap[K, V]) Backward() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		mm.locker.RLock()
+		defer mm.locker.RUnlock()
+
+		for node := mm.tree.Last(); node != nil; node = node.Prev() {
+			if !yield(node.Key(), node.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns an iterator over keys in the MultiMap, in ascending order
+func (mm *MultiMap[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		mm.locker.RLock()
+		defer mm.locker.RUnlock()
+
+		for node := mm.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the MultiMap, in ascending order of keys
+func (mm *MultiMap[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		mm.locker.RLock()
+		defer mm.locker.RUnlock()
+
+		for node := mm.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Value()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,83 @@
+//go:build go1.23
+
+package treemap
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/iterator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapIterSeq(t *testing.T) {
+	m := New[int, int](comparator.IntComparator, WithGoroutineSafe())
+	for i := 9; i >= 0; i-- {
+		m.Insert(i, i*10)
+	}
+
+	n := 0
+	for k, v := range m.All() {
+		assert.Equal(t, n, k)
+		assert.Equal(t, k*10, v)
+		n++
+	}
+	assert.Equal(t, 10, n)
+
+	for k, v := range m.Backward() {
+		n--
+		assert.Equal(t, n, k)
+		assert.Equal(t, k*10, v)
+	}
+
+	keys := make([]int, 0)
+	for k := range m.Keys() {
+		if k == 3 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{0, 1, 2}, keys)
+
+	sum := 0
+	for v := range m.Values() {
+		sum += v
+	}
+	assert.Equal(t, 450, sum)
+
+	keys = keys[:0]
+	for k, v := range iterator.KvSeq[int, int](m.LowerBound(4), m.UpperBound(6)) {
+		assert.Equal(t, k*10, v)
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{4, 5, 6}, keys)
+}
+
+func TestMultiMapIterSeq(t *testing.T) {
+	m := NewMultiMap[int, int](comparator.IntComparator)
+	for i := 0; i < 3; i++ {
+		m.Insert(i, i)
+		m.Insert(i, i+100)
+	}
+
+	keys := make([]int, 0)
+	for k := range m.Keys() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{0, 0, 1, 1, 2, 2}, keys)
+
+	keys = keys[:0]
+	for k := range m.Backward() {
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{2, 2, 1, 1, 0, 0}, keys)
+
+	count := 0
+	for range m.All() {
+		count++
+	}
+	for range m.Values() {
+		count++
+	}
+	assert.Equal(t, 12, count)
+}
@@ -3,6 +3,7 @@ package treemap
 import (
 	"errors"
 	"github.com/liyue201/gostl/ds/rbtree"
+	"github.com/liyue201/gostl/utils/codec"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/iterator"
 	"github.com/liyue201/gostl/utils/sync"
@@ -18,7 +19,9 @@ var ErrorNotFound = errors.New("not found")
 
 // Options holds Map's options
 type Options struct {
-	locker sync.Locker
+	locker     sync.Locker
+	keyCodec   any
+	valueCodec any
 }
 
 // Option is a function type used to set Options
@@ -33,10 +36,28 @@ func WithGoroutineSafe() Option {
 	}
 }
 
+// WithKeyCodec sets the codec used to encode keys in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of keys, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithKeyCodec[K any](c codec.Codec[K]) Option {
+	return func(option *Options) {
+		option.keyCodec = c
+	}
+}
+
+// WithValueCodec sets the codec used to encode values in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of values, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithValueCodec[V any](c codec.Codec[V]) Option {
+	return func(option *Options) {
+		option.valueCodec = c
+	}
+}
+
 // Map uses RbTress for internal data structure, and every key can must bee unique.
 type Map[K, V any] struct {
-	tree   *rbtree.RbTree[K, V]
-	locker sync.Locker
+	tree       *rbtree.RbTree[K, V]
+	locker     sync.Locker
+	keyCodec   codec.Codec[K]
+	valueCodec codec.Codec[V]
 }
 
 // New creates a new map
@@ -48,7 +69,9 @@ func New[K, V any](cmp comparator.Comparator[K], opts ...Option) *Map[K, V] {
 		opt(&option)
 	}
 	return &Map[K, V]{tree: rbtree.New[K, V](cmp),
-		locker: option.locker,
+		locker:     option.locker,
+		keyCodec:   codec.Get[K](option.keyCodec),
+		valueCodec: codec.Get[V](option.valueCodec),
 	}
 }
 
@@ -150,6 +173,42 @@ func (m *Map[K, V]) Last() *MapIterator[K, V] {
 	return &MapIterator[K, V]{node: m.tree.Last()}
 }
 
+//At returns the iterator of the k-th (counting from 0) node in the map, the iterator is invalid if k is out of range
+func (m *Map[K, V]) At(k int) *MapIterator[K, V] {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	return &MapIterator[K, V]{node: m.tree.Select(k)}
+}
+
+//IndexOf returns the position (counting from 0) of the passed key in the map, or -1 if the key is not in the map
+func (m *Map[K, V]) IndexOf(key K) int {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	if m.tree.FindNode(key) == nil {
+		return -1
+	}
+	return m.tree.Rank(key)
+}
+
+//Range traversals elements that their keys are in range [lo, hi) in the map, it will not stop until to the end of the range or the visitor returns false
+// The visitor may erase elements from the map unless it is goroutine-safe, in which case Range holds the read lock and erasing deadlocks
+func (m *Map[K, V]) Range(lo, hi K, visitor visitor.KvVisitor[K, V]) {
+	m.locker.RLock()
+	defer m.locker.RUnlock()
+
+	m.tree.RangeTraversal(lo, hi, visitor)
+}
+
+//EraseRange erases all nodes that their keys are in range [lo, hi) from the map, and returns the amount of erased nodes
+func (m *Map[K, V]) EraseRange(lo, hi K) int {
+	m.locker.Lock()
+	defer m.locker.Unlock()
+
+	return m.tree.DeleteRange(lo, hi)
+}
+
 //Clear clears the map
 func (m *Map[K, V]) Clear() {
 	m.locker.Lock()
@@ -97,3 +97,63 @@ func TestMap_Traversal(t *testing.T) {
 		return true
 	})
 }
+
+func TestMap_AtIndexOf(t *testing.T) {
+	m := New[int, int](comparator.IntComparator)
+	for i := 9; i >= 0; i-- {
+		m.Insert(i*10, i)
+	}
+	for i := 0; i < 10; i++ {
+		iter := m.At(i)
+		assert.Equal(t, i*10, iter.Key())
+		assert.Equal(t, i, iter.Value())
+		assert.Equal(t, i, m.IndexOf(i*10))
+	}
+	assert.False(t, m.At(10).IsValid())
+	assert.Equal(t, -1, m.IndexOf(5))
+
+	m.Erase(30)
+	assert.Equal(t, 40, m.At(3).Key())
+	assert.Equal(t, 3, m.IndexOf(40))
+}
+
+func TestMap_Range(t *testing.T) {
+	m := New[int, int](comparator.IntComparator, WithGoroutineSafe())
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i*10)
+	}
+
+	keys := make([]int, 0)
+	m.Range(10, 20, func(key, value int) bool {
+		assert.Equal(t, key*10, value)
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{10, 11, 12, 13, 14, 15, 16, 17, 18, 19}, keys)
+
+	assert.Equal(t, 10, m.EraseRange(10, 20))
+	assert.Equal(t, 90, m.Size())
+	assert.False(t, m.Contains(15))
+	assert.True(t, m.Contains(20))
+	assert.Equal(t, 0, m.EraseRange(10, 20))
+}
+
+func TestMap_RangeErase(t *testing.T) {
+	m := New[int, int](comparator.IntComparator)
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+	}
+	keys := make([]int, 0)
+	m.Range(10, 90, func(key, value int) bool {
+		keys = append(keys, key)
+		m.Erase(key)
+		return true
+	})
+	assert.Equal(t, 80, len(keys))
+	for i, key := range keys {
+		assert.Equal(t, 10+i, key)
+	}
+	assert.Equal(t, 20, m.Size())
+	assert.False(t, m.Contains(50))
+	assert.True(t, m.Contains(90))
+}
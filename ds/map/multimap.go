@@ -111,6 +111,42 @@ func (mm *MultiMap[K, V]) Last() *MapIterator[K, V] {
 	return &MapIterator[K, V]{node: mm.tree.Last()}
 }
 
+//At returns the iterator of the k-th (counting from 0) node in the MultiMap, the iterator is invalid if k is out of range
+func (mm *MultiMap[K, V]) At(k int) *MapIterator[K, V] {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	return &MapIterator[K, V]{node: mm.tree.Select(k)}
+}
+
+//IndexOf returns the position (counting from 0) of the first node with the passed key in the MultiMap, or -1 if the key is not in the MultiMap
+func (mm *MultiMap[K, V]) IndexOf(key K) int {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	if mm.tree.FindNode(key) == nil {
+		return -1
+	}
+	return mm.tree.Rank(key)
+}
+
+//Range traversals elements that their keys are in range [lo, hi) in the MultiMap, it will not stop until to the end of the range or the visitor returns false
+// The visitor may erase elements from the MultiMap unless it is goroutine-safe, in which case Range holds the read lock and erasing deadlocks
+func (mm *MultiMap[K, V]) Range(lo, hi K, visitor visitor.KvVisitor[K, V]) {
+	mm.locker.RLock()
+	defer mm.locker.RUnlock()
+
+	mm.tree.RangeTraversal(lo, hi, visitor)
+}
+
+//EraseRange erases all nodes that their keys are in range [lo, hi) from the MultiMap, and returns the amount of erased nodes
+func (mm *MultiMap[K, V]) EraseRange(lo, hi K) int {
+	mm.locker.Lock()
+	defer mm.locker.Unlock()
+
+	return mm.tree.DeleteRange(lo, hi)
+}
+
 //Clear clears the MultiMap
 func (mm *MultiMap[K, V]) Clear() {
 	mm.locker.Lock()
@@ -84,3 +84,55 @@ func TestMultiMap_Traversal(t *testing.T) {
 		return true
 	})
 }
+
+func TestMultiMap_AtIndexOf(t *testing.T) {
+	m := NewMultiMap[int, int](comparator.IntComparator)
+	for i := 1; i <= 5; i++ {
+		m.Insert(i, i)
+		m.Insert(i, i+1000)
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i/2+1, m.At(i).Key())
+	}
+	assert.False(t, m.At(-1).IsValid())
+	assert.Equal(t, 4, m.IndexOf(3))
+	assert.Equal(t, -1, m.IndexOf(6))
+}
+
+func TestMultiMap_Range(t *testing.T) {
+	m := NewMultiMap[int, int](comparator.IntComparator)
+	for i := 0; i < 10; i++ {
+		m.Insert(i, i)
+		m.Insert(i, i+1000)
+	}
+
+	count := 0
+	m.Range(3, 5, func(key, value int) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, 4, count)
+
+	assert.Equal(t, 4, m.EraseRange(3, 5))
+	assert.Equal(t, 16, m.Size())
+	assert.False(t, m.Contains(4))
+}
+
+func TestMultiMap_RangeErase(t *testing.T) {
+	m := NewMultiMap[int, int](comparator.IntComparator)
+	for i := 0; i < 100; i++ {
+		m.Insert(i, i)
+		m.Insert(i, i+1000)
+	}
+	keys := make([]int, 0)
+	m.Range(10, 90, func(key, value int) bool {
+		keys = append(keys, key)
+		m.Erase(key)
+		return true
+	})
+	assert.Equal(t, 80, len(keys))
+	for i, key := range keys {
+		assert.Equal(t, 10+i, key)
+	}
+	assert.Equal(t, 40, m.Size())
+}
@@ -0,0 +1,263 @@
+package minmaxheap
+
+import (
+	"math/bits"
+	gosync "sync"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+// Options holds MinMaxHeap's options
+type Options struct {
+	locker   sync.Locker
+	capacity int
+}
+
+// Option is a function type used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe is used to set the MinMaxHeap goroutine-safe
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// WithCapacity bounds the MinMaxHeap to capacity elements, the maximum element is evicted when a push exceeds
+// the capacity, so that the heap keeps the capacity minimum elements (top-K). capacity <= 0 means unbounded
+func WithCapacity(capacity int) Option {
+	return func(option *Options) {
+		option.capacity = capacity
+	}
+}
+
+// MinMaxHeap is a double-ended priority queue implemented by a min-max heap, both the minimum and the maximum
+// element can be accessed in O(1) time and removed in O(log n) time.
+// Elements on even levels are less than their descendants, and elements on odd levels are greater than their descendants
+type MinMaxHeap[T any] struct {
+	elements []T
+	cmp      comparator.Comparator[T]
+	capacity int
+	locker   sync.Locker
+}
+
+// New creates a MinMaxHeap
+func New[T any](cmp comparator.Comparator[T], opts ...Option) *MinMaxHeap[T] {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &MinMaxHeap[T]{
+		elements: make([]T, 0),
+		cmp:      cmp,
+		capacity: option.capacity,
+		locker:   option.locker,
+	}
+}
+
+// PushBoth pushes an element to the MinMaxHeap, the element can be popped from both ends.
+// In bounded mode, the maximum element is evicted if the capacity is exceeded
+func (h *MinMaxHeap[T]) PushBoth(e T) {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	if h.capacity > 0 && len(h.elements) >= h.capacity {
+		i := h.maxIndex()
+		if !h.less(e, h.elements[i]) {
+			// e is the maximum, evict it directly
+			return
+		}
+		h.removeAt(i)
+	}
+	h.elements = append(h.elements, e)
+	h.pushUp(len(h.elements) - 1)
+}
+
+// PopMin removes the minimum element from the MinMaxHeap and returns it
+func (h *MinMaxHeap[T]) PopMin() T {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	if len(h.elements) == 0 {
+		panic("heap is empty")
+	}
+	return h.removeAt(0)
+}
+
+// PopMax removes the maximum element from the MinMaxHeap and returns it
+func (h *MinMaxHeap[T]) PopMax() T {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	if len(h.elements) == 0 {
+		panic("heap is empty")
+	}
+	return h.removeAt(h.maxIndex())
+}
+
+// Min returns the minimum element in the MinMaxHeap
+func (h *MinMaxHeap[T]) Min() T {
+	h.locker.RLock()
+	defer h.locker.RUnlock()
+
+	if len(h.elements) == 0 {
+		panic("heap is empty")
+	}
+	return h.elements[0]
+}
+
+// Max returns the maximum element in the MinMaxHeap
+func (h *MinMaxHeap[T]) Max() T {
+	h.locker.RLock()
+	defer h.locker.RUnlock()
+
+	if len(h.elements) == 0 {
+		panic("heap is empty")
+	}
+	return h.elements[h.maxIndex()]
+}
+
+// Empty returns true if the MinMaxHeap is empty, otherwise returns false
+func (h *MinMaxHeap[T]) Empty() bool {
+	h.locker.RLock()
+	defer h.locker.RUnlock()
+
+	return len(h.elements) == 0
+}
+
+// Size returns the amount of elements in the MinMaxHeap
+func (h *MinMaxHeap[T]) Size() int {
+	h.locker.RLock()
+	defer h.locker.RUnlock()
+
+	return len(h.elements)
+}
+
+// Clear clears all elements in the MinMaxHeap
+func (h *MinMaxHeap[T]) Clear() {
+	h.locker.Lock()
+	defer h.locker.Unlock()
+
+	h.elements = h.elements[:0]
+}
+
+func (h *MinMaxHeap[T]) less(a, b T) bool {
+	return h.cmp(a, b) < 0
+}
+
+// maxIndex returns the index of the maximum element, the heap must not be empty
+func (h *MinMaxHeap[T]) maxIndex() int {
+	switch len(h.elements) {
+	case 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if h.less(h.elements[1], h.elements[2]) {
+		return 2
+	}
+	return 1
+}
+
+// removeAt removes the element at index i and returns it
+func (h *MinMaxHeap[T]) removeAt(i int) T {
+	e := h.elements[i]
+	last := len(h.elements) - 1
+	h.elements[i] = h.elements[last]
+	h.elements[last] = *new(T)
+	h.elements = h.elements[:last]
+	if i < last {
+		h.pushDown(i)
+	}
+	return e
+}
+
+func (h *MinMaxHeap[T]) swap(i, j int) {
+	h.elements[i], h.elements[j] = h.elements[j], h.elements[i]
+}
+
+// isMinLevel returns true if the index i is on a min level (even level)
+func isMinLevel(i int) bool {
+	return bits.Len(uint(i+1))%2 == 1
+}
+
+func (h *MinMaxHeap[T]) pushUp(i int) {
+	if i == 0 {
+		return
+	}
+	parent := (i - 1) / 2
+	if isMinLevel(i) {
+		if h.less(h.elements[parent], h.elements[i]) {
+			h.swap(i, parent)
+			h.pushUpLevel(parent, false)
+		} else {
+			h.pushUpLevel(i, true)
+		}
+	} else {
+		if h.less(h.elements[i], h.elements[parent]) {
+			h.swap(i, parent)
+			h.pushUpLevel(parent, true)
+		} else {
+			h.pushUpLevel(i, false)
+		}
+	}
+}
+
+// pushUpLevel moves the element at index i up through its grandparents on min levels (if min is true) or max levels
+func (h *MinMaxHeap[T]) pushUpLevel(i int, min bool) {
+	for i > 2 {
+		grandparent := ((i-1)/2 - 1) / 2
+		if h.less(h.elements[i], h.elements[grandparent]) != min {
+			return
+		}
+		h.swap(i, grandparent)
+		i = grandparent
+	}
+}
+
+// pushDown moves the element at index i down to restore the min-max heap order
+func (h *MinMaxHeap[T]) pushDown(i int) {
+	min := isMinLevel(i)
+	// better returns true if a should be closer to the root than b on the level of i
+	better := func(a, b int) bool {
+		if min {
+			return h.less(h.elements[a], h.elements[b])
+		}
+		return h.less(h.elements[b], h.elements[a])
+	}
+	n := len(h.elements)
+	for {
+		first := 2*i + 1
+		if first >= n {
+			return
+		}
+		// find the best one of children and grandchildren
+		m := first
+		candidates := [...]int{first + 1, 2*first + 1, 2*first + 2, 2*first + 3, 2*first + 4}
+		for _, c := range candidates {
+			if c < n && better(c, m) {
+				m = c
+			}
+		}
+		if !better(m, i) {
+			return
+		}
+		h.swap(m, i)
+		if m <= first+1 {
+			// m is a child
+			return
+		}
+		// m is a grandchild, its parent is on the opposite kind of level
+		if parent := (m - 1) / 2; better(parent, m) {
+			h.swap(m, parent)
+		}
+		i = m
+	}
+}
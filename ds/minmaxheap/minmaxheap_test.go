@@ -0,0 +1,110 @@
+package minmaxheap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+// checkHeap checks the min-max heap property
+func checkHeap(t *testing.T, h *MinMaxHeap[int]) {
+	for i := 1; i < len(h.elements); i++ {
+		for p := (i - 1) / 2; ; p = (p - 1) / 2 {
+			if isMinLevel(p) {
+				assert.LessOrEqual(t, h.elements[p], h.elements[i])
+			} else {
+				assert.GreaterOrEqual(t, h.elements[p], h.elements[i])
+			}
+			if p == 0 {
+				break
+			}
+		}
+	}
+}
+
+func TestMinMaxHeap(t *testing.T) {
+	h := New(comparator.IntComparator, WithGoroutineSafe())
+	assert.True(t, h.Empty())
+	assert.Panics(t, func() { h.PopMin() })
+	assert.Panics(t, func() { h.PopMax() })
+	assert.Panics(t, func() { h.Min() })
+	assert.Panics(t, func() { h.Max() })
+
+	h.PushBoth(5)
+	assert.Equal(t, 5, h.Min())
+	assert.Equal(t, 5, h.Max())
+	for _, v := range []int{3, 8, 1, 9, 2} {
+		h.PushBoth(v)
+	}
+	assert.Equal(t, 6, h.Size())
+	assert.Equal(t, 1, h.Min())
+	assert.Equal(t, 9, h.Max())
+	assert.Equal(t, 9, h.PopMax())
+	assert.Equal(t, 1, h.PopMin())
+	assert.Equal(t, 8, h.PopMax())
+	assert.Equal(t, 2, h.PopMin())
+	assert.Equal(t, 5, h.PopMax())
+	assert.Equal(t, 3, h.PopMax())
+	assert.True(t, h.Empty())
+
+	h.PushBoth(1)
+	h.Clear()
+	assert.Equal(t, 0, h.Size())
+}
+
+func TestMinMaxHeapRandom(t *testing.T) {
+	h := New(comparator.IntComparator)
+	values := make([]int, 0)
+	for i := 0; i < 3000; i++ {
+		switch rand.Intn(4) {
+		case 0, 1:
+			v := rand.Intn(1000)
+			h.PushBoth(v)
+			values = append(values, v)
+		case 2:
+			if len(values) > 0 {
+				sort.Ints(values)
+				assert.Equal(t, values[0], h.PopMin())
+				values = values[1:]
+			}
+		default:
+			if len(values) > 0 {
+				sort.Ints(values)
+				assert.Equal(t, values[len(values)-1], h.PopMax())
+				values = values[:len(values)-1]
+			}
+		}
+		if i%100 == 0 {
+			checkHeap(t, h)
+		}
+	}
+	assert.Equal(t, len(values), h.Size())
+	checkHeap(t, h)
+}
+
+func TestMinMaxHeapBounded(t *testing.T) {
+	h := New(comparator.IntComparator, WithCapacity(10))
+	values := rand.Perm(1000)
+	for _, v := range values {
+		h.PushBoth(v)
+		assert.LessOrEqual(t, h.Size(), 10)
+	}
+	checkHeap(t, h)
+	assert.Equal(t, 9, h.Max())
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i, h.PopMin())
+	}
+
+	// keep the 5 largest elements with a reversed comparator
+	top := New(comparator.Reverse(comparator.IntComparator), WithCapacity(5))
+	for _, v := range values {
+		top.PushBoth(v)
+	}
+	for i := 999; i >= 995; i-- {
+		assert.Equal(t, i, top.PopMin())
+	}
+	assert.True(t, top.Empty())
+}
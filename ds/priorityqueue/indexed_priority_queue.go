@@ -0,0 +1,188 @@
+package priorityqueue
+
+import (
+	"github.com/liyue201/gostl/ds/heap"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+)
+
+// Handle refers to an element in an IndexedPriorityQueue, it is returned by Push and
+// can be used to update or remove the element later
+type Handle[T any] struct {
+	value T
+	index int // position in the heap, -1 if the element has been removed from the queue
+}
+
+// Value returns the element's value of the handle
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// handleHolder holds handles of the IndexedPriorityQueue, and keeps the heap positions in handles
+type handleHolder[T any] struct {
+	handles []*Handle[T]
+	cmpFun  comparator.Comparator[T]
+}
+
+// Push pushes a handle to the handleHolder
+func (h *handleHolder[T]) Push(handle *Handle[T]) {
+	handle.index = len(h.handles)
+	h.handles = append(h.handles, handle)
+}
+
+// Pop pops a handle from the handleHolder
+func (h *handleHolder[T]) Pop() *Handle[T] {
+	if len(h.handles) == 0 {
+		panic("queue is empty")
+	}
+	handle := h.handles[len(h.handles)-1]
+	h.handles[len(h.handles)-1] = nil
+	h.handles = h.handles[:len(h.handles)-1]
+	handle.index = -1
+	return handle
+}
+
+// Len returns the amount of handles in handleHolder
+func (h *handleHolder[T]) Len() int {
+	return len(h.handles)
+}
+
+// Less compares two elements at position i and j, and returns true if elements[i] < elements[j]
+func (h *handleHolder[T]) Less(i, j int) bool {
+	return h.cmpFun(h.handles[i].value, h.handles[j].value) < 0
+}
+
+// Swap swaps two handles at position i and j, and updates their positions
+func (h *handleHolder[T]) Swap(i, j int) {
+	h.handles[i], h.handles[j] = h.handles[j], h.handles[i]
+	h.handles[i].index = i
+	h.handles[j].index = j
+}
+
+func (h *handleHolder[T]) top() *Handle[T] {
+	if len(h.handles) == 0 {
+		panic("queue is empty")
+	}
+	return h.handles[0]
+}
+
+func (h *handleHolder[T]) contains(handle *Handle[T]) bool {
+	return handle != nil && handle.index >= 0 && handle.index < len(h.handles) && h.handles[handle.index] == handle
+}
+
+// IndexedPriorityQueue is a priority queue whose elements can be updated or removed through the handles returned by Push
+type IndexedPriorityQueue[T any] struct {
+	holder *handleHolder[T]
+	locker sync.Locker
+}
+
+// NewIndexed creates an IndexedPriorityQueue
+func NewIndexed[T any](cmp comparator.Comparator[T], opts ...Option) *IndexedPriorityQueue[T] {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &IndexedPriorityQueue[T]{
+		holder: &handleHolder[T]{cmpFun: cmp},
+		locker: option.locker,
+	}
+}
+
+// Push pushes an element to the IndexedPriorityQueue and returns its handle
+func (q *IndexedPriorityQueue[T]) Push(e T) *Handle[T] {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	handle := &Handle[T]{value: e}
+	heap.Push[*Handle[T]](q.holder, handle)
+	return handle
+}
+
+// Pop pops the top element from the IndexedPriorityQueue, its handle becomes invalid
+func (q *IndexedPriorityQueue[T]) Pop() T {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	return heap.Pop[*Handle[T]](q.holder).value
+}
+
+// Top returns the top element in the IndexedPriorityQueue
+func (q *IndexedPriorityQueue[T]) Top() T {
+	q.locker.RLock()
+	defer q.locker.RUnlock()
+
+	return q.holder.top().value
+}
+
+// TopHandle returns the handle of the top element in the IndexedPriorityQueue
+func (q *IndexedPriorityQueue[T]) TopHandle() *Handle[T] {
+	q.locker.RLock()
+	defer q.locker.RUnlock()
+
+	return q.holder.top()
+}
+
+// Update changes the element's value of the handle and restores the heap order,
+// it returns false if the handle is not in the IndexedPriorityQueue
+func (q *IndexedPriorityQueue[T]) Update(handle *Handle[T], value T) bool {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	if !q.holder.contains(handle) {
+		return false
+	}
+	handle.value = value
+	heap.Fix[*Handle[T]](q.holder, handle.index)
+	return true
+}
+
+// Remove removes the element of the handle from the IndexedPriorityQueue,
+// it returns false if the handle is not in the IndexedPriorityQueue
+func (q *IndexedPriorityQueue[T]) Remove(handle *Handle[T]) bool {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	if !q.holder.contains(handle) {
+		return false
+	}
+	heap.Remove[*Handle[T]](q.holder, handle.index)
+	return true
+}
+
+// Contains returns true if the element of the handle is in the IndexedPriorityQueue
+func (q *IndexedPriorityQueue[T]) Contains(handle *Handle[T]) bool {
+	q.locker.RLock()
+	defer q.locker.RUnlock()
+
+	return q.holder.contains(handle)
+}
+
+// Empty returns true if the IndexedPriorityQueue is empty, otherwise returns false
+func (q *IndexedPriorityQueue[T]) Empty() bool {
+	q.locker.RLock()
+	defer q.locker.RUnlock()
+
+	return q.holder.Len() == 0
+}
+
+// Size returns the amount of elements in the IndexedPriorityQueue
+func (q *IndexedPriorityQueue[T]) Size() int {
+	q.locker.RLock()
+	defer q.locker.RUnlock()
+
+	return q.holder.Len()
+}
+
+// Clear clears all elements in the IndexedPriorityQueue, all handles become invalid
+func (q *IndexedPriorityQueue[T]) Clear() {
+	q.locker.Lock()
+	defer q.locker.Unlock()
+
+	for i, handle := range q.holder.handles {
+		handle.index = -1
+		q.holder.handles[i] = nil
+	}
+	q.holder.handles = q.holder.handles[:0]
+}
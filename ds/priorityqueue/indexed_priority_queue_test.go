@@ -0,0 +1,77 @@
+package priorityqueue
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexedPriorityQueue(t *testing.T) {
+	pq := NewIndexed(comparator.IntComparator, WithGoroutineSafe())
+	h4 := pq.Push(4)
+	h8 := pq.Push(8)
+	h1 := pq.Push(1)
+	pq.Push(6)
+	h3 := pq.Push(3)
+	assert.Equal(t, 5, pq.Size())
+	assert.Equal(t, 1, pq.Top())
+	assert.Equal(t, h1, pq.TopHandle())
+
+	assert.True(t, pq.Update(h8, 0))
+	assert.Equal(t, 0, pq.Top())
+	assert.Equal(t, 0, h8.Value())
+	assert.True(t, pq.Update(h8, 10))
+	assert.True(t, pq.Remove(h1))
+	assert.False(t, pq.Contains(h1))
+	assert.False(t, pq.Remove(h1))
+	assert.False(t, pq.Update(h1, 2))
+	assert.True(t, pq.Contains(h4))
+
+	assert.Equal(t, 3, pq.Pop())
+	assert.False(t, pq.Contains(h3))
+	assert.Equal(t, 4, pq.Pop())
+	assert.Equal(t, 6, pq.Pop())
+	assert.Equal(t, 10, pq.Pop())
+	assert.True(t, pq.Empty())
+
+	// handles of other queues are not contained
+	other := NewIndexed(comparator.IntComparator)
+	assert.False(t, pq.Contains(other.Push(1)))
+	assert.False(t, pq.Contains(nil))
+
+	h := pq.Push(1)
+	pq.Clear()
+	assert.False(t, pq.Contains(h))
+	assert.Equal(t, 0, pq.Size())
+}
+
+func TestIndexedPriorityQueueRandom(t *testing.T) {
+	pq := NewIndexed(comparator.IntComparator)
+	handles := make([]*Handle[int], 0)
+	for i := 0; i < 1000; i++ {
+		handles = append(handles, pq.Push(rand.Intn(10000)))
+	}
+	for i := 0; i < 500; i++ {
+		h := handles[rand.Intn(len(handles))]
+		if rand.Intn(2) == 0 {
+			pq.Update(h, rand.Intn(10000))
+		} else {
+			pq.Remove(h)
+		}
+	}
+
+	expected := make([]int, 0)
+	for _, h := range handles {
+		if pq.Contains(h) {
+			expected = append(expected, h.Value())
+		}
+	}
+	sort.Ints(expected)
+	assert.Equal(t, len(expected), pq.Size())
+	for _, v := range expected {
+		assert.Equal(t, v, pq.Pop())
+	}
+}
@@ -0,0 +1,16 @@
+//go:build go1.23
+
+package priorityqueue
+
+import "iter"
+
+// Values returns an iterator over elements in the PriorityQueue.
+// Note that the elements are visited in the internal heap order, not in priority order
+func (q *PriorityQueue[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		q.locker.RLock()
+		defer q.locker.RUnlock()
+
+		q.holder.traversal(yield)
+	}
+}
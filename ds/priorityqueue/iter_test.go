@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package priorityqueue
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriorityQueueIterSeq(t *testing.T) {
+	q := New[int](comparator.IntComparator, WithGoroutineSafe())
+	for _, v := range []int{5, 3, 8, 1, 9} {
+		q.Push(v)
+	}
+
+	values := make([]int, 0)
+	for v := range q.Values() {
+		values = append(values, v)
+	}
+	assert.Equal(t, 1, values[0])
+	sort.Ints(values)
+	assert.Equal(t, []int{1, 3, 5, 8, 9}, values)
+	assert.Equal(t, 5, q.Size())
+}
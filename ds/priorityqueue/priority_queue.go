@@ -6,12 +6,31 @@ import (
 	"github.com/liyue201/gostl/ds/heap"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
 )
 
 var (
 	defaultLocker sync.FakeLocker
 )
 
+// kinds of heap used by PriorityQueue
+const (
+	binaryHeap = iota
+	daryHeap
+	pairingHeap
+	fibonacciHeap
+)
+
+// container is the heap used by PriorityQueue internally
+type container[T any] interface {
+	push(element T)
+	pop() T
+	top() T
+	size() int
+	clear()
+	traversal(visitor visitor.Visitor[T])
+}
+
 // ElementHolder holds elements of the PriorityQueue
 type ElementHolder[T any] struct {
 	elements []T
@@ -33,6 +52,14 @@ func (h *ElementHolder[T]) Pop() T {
 	return item
 }
 
+func (h *ElementHolder[T]) push(element T) {
+	heap.Push[T](h, element)
+}
+
+func (h *ElementHolder[T]) pop() T {
+	return heap.Pop[T](h)
+}
+
 func (h *ElementHolder[T]) top() T {
 	if len(h.elements) == 0 {
 		panic("queue is empty")
@@ -40,6 +67,23 @@ func (h *ElementHolder[T]) top() T {
 	return h.elements[0]
 }
 
+func (h *ElementHolder[T]) size() int {
+	return len(h.elements)
+}
+
+func (h *ElementHolder[T]) clear() {
+	// reset cap to zero
+	h.elements = h.elements[:0]
+}
+
+func (h *ElementHolder[T]) traversal(visitor visitor.Visitor[T]) {
+	for _, e := range h.elements {
+		if !visitor(e) {
+			return
+		}
+	}
+}
+
 // Len returns the amount of elements in ElementHolder
 func (h *ElementHolder[T]) Len() int {
 	return len(h.elements)
@@ -55,9 +99,47 @@ func (h *ElementHolder[T]) Swap(i, j int) {
 	h.elements[i], h.elements[j] = h.elements[j], h.elements[i]
 }
 
+// heapHolder holds elements of the PriorityQueue in a heap.Heap
+type heapHolder[T any] struct {
+	heap    heap.Heap[T]
+	newHeap func() heap.Heap[T]
+}
+
+func (h *heapHolder[T]) push(element T) {
+	h.heap.Push(element)
+}
+
+func (h *heapHolder[T]) pop() T {
+	if h.heap.Len() == 0 {
+		panic("queue is empty")
+	}
+	return h.heap.Pop()
+}
+
+func (h *heapHolder[T]) top() T {
+	if h.heap.Len() == 0 {
+		panic("queue is empty")
+	}
+	return h.heap.Peek()
+}
+
+func (h *heapHolder[T]) size() int {
+	return h.heap.Len()
+}
+
+func (h *heapHolder[T]) clear() {
+	h.heap = h.newHeap()
+}
+
+func (h *heapHolder[T]) traversal(visitor visitor.Visitor[T]) {
+	h.heap.Traversal(visitor)
+}
+
 // Options holds PriorityQueue's options
 type Options struct {
-	locker sync.Locker
+	locker   sync.Locker
+	heapKind int
+	arity    int
 }
 
 // Option is a function type used to set Options
@@ -70,9 +152,31 @@ func WithGoroutineSafe() Option {
 	}
 }
 
-// PriorityQueue is an implementation of priority queue
+// WithDaryHeap makes the PriorityQueue use a heap.DaryHeap with d children per node
+func WithDaryHeap(d int) Option {
+	return func(option *Options) {
+		option.heapKind = daryHeap
+		option.arity = d
+	}
+}
+
+// WithPairingHeap makes the PriorityQueue use a heap.PairingHeap
+func WithPairingHeap() Option {
+	return func(option *Options) {
+		option.heapKind = pairingHeap
+	}
+}
+
+// WithFibonacciHeap makes the PriorityQueue use a heap.FibonacciHeap
+func WithFibonacciHeap() Option {
+	return func(option *Options) {
+		option.heapKind = fibonacciHeap
+	}
+}
+
+// PriorityQueue is an implementation of priority queue, it uses a binary heap by default
 type PriorityQueue[T any] struct {
-	holder *ElementHolder[T]
+	holder container[T]
 	locker sync.Locker
 }
 
@@ -84,22 +188,36 @@ func New[T any](cmp comparator.Comparator[T], opts ...Option) *PriorityQueue[T]
 	for _, opt := range opts {
 		opt(&option)
 	}
-	holder := &ElementHolder[T]{
-		elements: make([]T, 0),
-		cmpFun:   cmp,
-	}
 	return &PriorityQueue[T]{
-		holder: holder,
+		holder: newContainer(cmp, option),
 		locker: option.locker,
 	}
 }
 
+func newContainer[T any](cmp comparator.Comparator[T], option Options) container[T] {
+	var newHeap func() heap.Heap[T]
+	switch option.heapKind {
+	case daryHeap:
+		newHeap = func() heap.Heap[T] { return heap.NewDary[T](option.arity, cmp) }
+	case pairingHeap:
+		newHeap = func() heap.Heap[T] { return heap.NewPairing[T](cmp) }
+	case fibonacciHeap:
+		newHeap = func() heap.Heap[T] { return heap.NewFibonacci[T](cmp) }
+	default:
+		return &ElementHolder[T]{
+			elements: make([]T, 0),
+			cmpFun:   cmp,
+		}
+	}
+	return &heapHolder[T]{heap: newHeap(), newHeap: newHeap}
+}
+
 // Push pushes an element to the PriorityQueue
 func (q *PriorityQueue[T]) Push(e T) {
 	q.locker.Lock()
 	defer q.locker.Unlock()
 
-	heap.Push[T](q.holder, e)
+	q.holder.push(e)
 }
 
 // Pop pops an element from the PriorityQueue
@@ -107,7 +225,7 @@ func (q *PriorityQueue[T]) Pop() T {
 	q.locker.Lock()
 	defer q.locker.Unlock()
 
-	return heap.Pop[T](q.holder)
+	return q.holder.pop()
 }
 
 // Top returns the top element in the PriorityQueue
@@ -123,7 +241,7 @@ func (q *PriorityQueue[T]) Empty() bool {
 	q.locker.RLock()
 	defer q.locker.RUnlock()
 
-	return q.holder.Len() == 0
+	return q.holder.size() == 0
 }
 
 // Clear clears all elements in the priority queue
@@ -131,8 +249,7 @@ func (q *PriorityQueue[T]) Clear() {
 	q.locker.Lock()
 	defer q.locker.Unlock()
 
-	// reset cap to zero
-	q.holder.elements = q.holder.elements[:0]
+	q.holder.clear()
 }
 
 // Size returns the amount of elements in the queue
@@ -140,5 +257,5 @@ func (q *PriorityQueue[T]) Size() int {
 	q.locker.RLock()
 	defer q.locker.RUnlock()
 
-	return q.holder.Len()
+	return q.holder.size()
 }
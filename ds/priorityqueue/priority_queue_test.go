@@ -1,9 +1,12 @@
 package priorityqueue
 
 import (
+	"math/rand"
+	"sort"
 	"testing"
 
 	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestMinPriorityQueue(t *testing.T) {
@@ -65,3 +68,114 @@ func TestStringPriorityQueue(t *testing.T) {
 		t.Logf("%v, %v", pq.Top(), pq.Pop())
 	}
 }
+
+func TestPriorityQueueClear(t *testing.T) {
+	pq := New(comparator.IntComparator)
+	values := rand.Perm(100)
+	for _, v := range values {
+		pq.Push(v)
+	}
+	assert.Equal(t, 100, pq.Size())
+	assert.Equal(t, 0, pq.Top())
+	sort.Ints(values)
+	for _, v := range values[:50] {
+		assert.Equal(t, v, pq.Pop())
+	}
+	pq.Clear()
+	assert.True(t, pq.Empty())
+	assert.Panics(t, func() { pq.Pop() })
+	assert.Panics(t, func() { pq.Top() })
+	pq.Push(1)
+	assert.Equal(t, 1, pq.Top())
+}
+
+func TestPriorityQueueDaryHeap(t *testing.T) {
+	pq := New(comparator.IntComparator, WithDaryHeap(4))
+	values := rand.Perm(100)
+	for _, v := range values {
+		pq.Push(v)
+	}
+	assert.Equal(t, 100, pq.Size())
+	assert.Equal(t, 0, pq.Top())
+	sort.Ints(values)
+	for _, v := range values[:50] {
+		assert.Equal(t, v, pq.Pop())
+	}
+	pq.Clear()
+	assert.True(t, pq.Empty())
+	assert.Panics(t, func() { pq.Pop() })
+	assert.Panics(t, func() { pq.Top() })
+	pq.Push(1)
+	assert.Equal(t, 1, pq.Top())
+}
+
+func TestPriorityQueuePairingHeap(t *testing.T) {
+	pq := New(comparator.IntComparator, WithPairingHeap())
+	values := rand.Perm(100)
+	for _, v := range values {
+		pq.Push(v)
+	}
+	assert.Equal(t, 100, pq.Size())
+	assert.Equal(t, 0, pq.Top())
+	sort.Ints(values)
+	for _, v := range values[:50] {
+		assert.Equal(t, v, pq.Pop())
+	}
+	pq.Clear()
+	assert.True(t, pq.Empty())
+	assert.Panics(t, func() { pq.Pop() })
+	assert.Panics(t, func() { pq.Top() })
+	pq.Push(1)
+	assert.Equal(t, 1, pq.Top())
+}
+
+func TestPriorityQueueFibonacciHeap(t *testing.T) {
+	pq := New(comparator.IntComparator, WithFibonacciHeap())
+	values := rand.Perm(100)
+	for _, v := range values {
+		pq.Push(v)
+	}
+	assert.Equal(t, 100, pq.Size())
+	assert.Equal(t, 0, pq.Top())
+	sort.Ints(values)
+	for _, v := range values[:50] {
+		assert.Equal(t, v, pq.Pop())
+	}
+	pq.Clear()
+	assert.True(t, pq.Empty())
+	assert.Panics(t, func() { pq.Pop() })
+	assert.Panics(t, func() { pq.Top() })
+	pq.Push(1)
+	assert.Equal(t, 1, pq.Top())
+}
+
+func benchmarkPriorityQueue(b *testing.B, opts ...Option) {
+	const n = 10000
+	values := rand.Perm(n)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		pq := New(comparator.IntComparator, opts...)
+		for _, v := range values {
+			pq.Push(v)
+		}
+		for !pq.Empty() {
+			pq.Pop()
+		}
+	}
+}
+
+func BenchmarkPriorityQueueElementHolder(b *testing.B) {
+	benchmarkPriorityQueue(b)
+}
+
+func BenchmarkPriorityQueue4aryHeap(b *testing.B) {
+	benchmarkPriorityQueue(b, WithDaryHeap(4))
+}
+
+func BenchmarkPriorityQueuePairingHeap(b *testing.B) {
+	benchmarkPriorityQueue(b, WithPairingHeap())
+}
+
+func BenchmarkPriorityQueueFibonacciHeap(b *testing.B) {
+	benchmarkPriorityQueue(b, WithFibonacciHeap())
+}
@@ -0,0 +1,237 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	gosync "sync"
+
+	"github.com/liyue201/gostl/ds/container"
+	"github.com/liyue201/gostl/ds/deque"
+)
+
+var (
+	ErrorClosed = errors.New("queue is closed")
+	ErrorFull   = errors.New("queue is full")
+	ErrorEmpty  = errors.New("queue is empty")
+)
+
+// signal wakes up all goroutines waiting on it
+type signal struct {
+	ch      chan struct{}
+	waiters int
+}
+
+func newSignal() signal {
+	return signal{ch: make(chan struct{})}
+}
+
+// wait returns a channel which will be closed by broadcast, it must be called with the lock held
+func (s *signal) wait() <-chan struct{} {
+	s.waiters++
+	return s.ch
+}
+
+// broadcast wakes up all waiters, it must be called with the lock held
+func (s *signal) broadcast() {
+	if s.waiters == 0 {
+		return
+	}
+	close(s.ch)
+	s.ch = make(chan struct{})
+	s.waiters = 0
+}
+
+// BlockingQueue is a goroutine-safe first-in-first-out queue with an optional capacity,
+// Push blocks while the queue is full and Pop blocks while the queue is empty.
+// After Close, pushes fail with ErrorClosed, and pops keep returning the remaining elements until the queue is drained
+type BlockingQueue[T any] struct {
+	container container.Container[T]
+	capacity  int
+	mutex     gosync.Mutex
+	notEmpty  signal
+	notFull   signal
+	closed    bool
+}
+
+// NewBlocking creates a BlockingQueue which holds at most capacity elements, capacity <= 0 means unbounded.
+// The WithGoroutineSafe option is useless since BlockingQueue is always goroutine-safe
+func NewBlocking[T any](capacity int, opts ...Option[T]) *BlockingQueue[T] {
+	option := Options[T]{
+		container: deque.New[T](),
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	return &BlockingQueue[T]{
+		container: option.container,
+		capacity:  capacity,
+		notEmpty:  newSignal(),
+		notFull:   newSignal(),
+	}
+}
+
+// Push pushes a value to the end of the queue, it blocks until there is room in the queue.
+// It returns ErrorClosed if the queue is closed
+func (q *BlockingQueue[T]) Push(value T) error {
+	return q.PushCtx(context.Background(), value)
+}
+
+// PushCtx pushes a value to the end of the queue, it blocks until there is room in the queue or ctx is done.
+// It returns ErrorClosed if the queue is closed, or ctx.Err() if ctx is done
+func (q *BlockingQueue[T]) PushCtx(ctx context.Context, value T) error {
+	for {
+		q.mutex.Lock()
+		if q.closed {
+			q.mutex.Unlock()
+			return ErrorClosed
+		}
+		if !q.full() {
+			q.container.PushBack(value)
+			q.notEmpty.broadcast()
+			q.mutex.Unlock()
+			return nil
+		}
+		ch := q.notFull.wait()
+		q.mutex.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// TryPush pushes a value to the end of the queue without blocking,
+// it returns ErrorFull if the queue is full, or ErrorClosed if the queue is closed
+func (q *BlockingQueue[T]) TryPush(value T) error {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.closed {
+		return ErrorClosed
+	}
+	if q.full() {
+		return ErrorFull
+	}
+	q.container.PushBack(value)
+	q.notEmpty.broadcast()
+	return nil
+}
+
+// Pop removes the front element in the queue and returns its value, it blocks until the queue is not empty.
+// It returns ErrorClosed if the queue is closed and drained
+func (q *BlockingQueue[T]) Pop() (T, error) {
+	return q.PopCtx(context.Background())
+}
+
+// PopCtx removes the front element in the queue and returns its value, it blocks until the queue is not empty or ctx is done.
+// It returns ErrorClosed if the queue is closed and drained, or ctx.Err() if ctx is done
+func (q *BlockingQueue[T]) PopCtx(ctx context.Context) (T, error) {
+	values, err := q.PopBatchCtx(ctx, 1)
+	if err != nil {
+		return *new(T), err
+	}
+	return values[0], nil
+}
+
+// TryPop removes the front element in the queue and returns its value without blocking,
+// it returns ErrorEmpty if the queue is empty, or ErrorClosed if the queue is closed and drained
+func (q *BlockingQueue[T]) TryPop() (T, error) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if q.container.Empty() {
+		if q.closed {
+			return *new(T), ErrorClosed
+		}
+		return *new(T), ErrorEmpty
+	}
+	return q.popBatch(1)[0], nil
+}
+
+// PopBatch removes at most max (at least 1) elements from the front of the queue and returns them, it blocks until the queue is not empty.
+// It returns ErrorClosed if the queue is closed and drained
+func (q *BlockingQueue[T]) PopBatch(max int) ([]T, error) {
+	return q.PopBatchCtx(context.Background(), max)
+}
+
+// PopBatchCtx removes at most max elements from the front of the queue and returns them, it blocks until the queue
+// is not empty or ctx is done. It returns ErrorClosed if the queue is closed and drained, or ctx.Err() if ctx is done
+func (q *BlockingQueue[T]) PopBatchCtx(ctx context.Context, max int) ([]T, error) {
+	for {
+		q.mutex.Lock()
+		if !q.container.Empty() {
+			values := q.popBatch(max)
+			q.mutex.Unlock()
+			return values, nil
+		}
+		if q.closed {
+			q.mutex.Unlock()
+			return nil, ErrorClosed
+		}
+		ch := q.notEmpty.wait()
+		q.mutex.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// Close closes the queue and wakes up all blocked goroutines, the remaining elements can still be popped
+func (q *BlockingQueue[T]) Close() {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	q.closed = true
+	q.notEmpty.broadcast()
+	q.notFull.broadcast()
+}
+
+// IsClosed returns true if the queue is closed
+func (q *BlockingQueue[T]) IsClosed() bool {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.closed
+}
+
+// Size returns the amount of elements in the queue
+func (q *BlockingQueue[T]) Size() int {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	return q.container.Size()
+}
+
+// Capacity returns the capacity of the queue, 0 means unbounded
+func (q *BlockingQueue[T]) Capacity() int {
+	if q.capacity <= 0 {
+		return 0
+	}
+	return q.capacity
+}
+
+func (q *BlockingQueue[T]) full() bool {
+	return q.capacity > 0 && q.container.Size() >= q.capacity
+}
+
+// popBatch pops at most max elements, at least one element is popped. It must be called with the lock held
+func (q *BlockingQueue[T]) popBatch(max int) []T {
+	if max < 1 {
+		max = 1
+	}
+	n := q.container.Size()
+	if n > max {
+		n = max
+	}
+	values := make([]T, n)
+	for i := range values {
+		values[i] = q.container.PopFront()
+	}
+	q.notFull.broadcast()
+	return values
+}
@@ -0,0 +1,145 @@
+package queue
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockingQueue(t *testing.T) {
+	q := NewBlocking[int](3, WithListContainer[int]())
+	assert.Equal(t, 3, q.Capacity())
+
+	for i := 0; i < 3; i++ {
+		assert.Nil(t, q.TryPush(i))
+	}
+	assert.Equal(t, ErrorFull, q.TryPush(3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, q.PushCtx(ctx, 3))
+	assert.Equal(t, 3, q.Size())
+
+	v, err := q.TryPop()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, v)
+	values, err := q.PopBatch(10)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, values)
+	_, err = q.TryPop()
+	assert.Equal(t, ErrorEmpty, err)
+
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	cancel2()
+	_, err = q.PopCtx(ctx2)
+	assert.Equal(t, context.Canceled, err)
+}
+
+func TestBlockingQueueBlocking(t *testing.T) {
+	q := NewBlocking[int](1)
+	done := make(chan int)
+	go func() {
+		v, err := q.Pop()
+		assert.Nil(t, err)
+		done <- v
+	}()
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, q.Push(1))
+	assert.Equal(t, 1, <-done)
+
+	assert.Nil(t, q.Push(2))
+	go func() {
+		// blocks until 2 is popped
+		assert.Nil(t, q.Push(3))
+		done <- 3
+	}()
+	time.Sleep(10 * time.Millisecond)
+	v, _ := q.Pop()
+	assert.Equal(t, 2, v)
+	assert.Equal(t, 3, <-done)
+	v, _ = q.Pop()
+	assert.Equal(t, 3, v)
+}
+
+func TestBlockingQueueClose(t *testing.T) {
+	q := NewBlocking[int](0)
+	assert.Equal(t, 0, q.Capacity())
+	errs := make(chan error)
+	go func() {
+		_, err := q.Pop()
+		errs <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert.Equal(t, ErrorClosed, <-errs)
+	assert.True(t, q.IsClosed())
+
+	// the remaining elements can be drained after Close
+	q = NewBlocking[int](2)
+	q.Push(1)
+	q.Push(2)
+	go func() {
+		errs <- q.Push(3)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	assert.Equal(t, ErrorClosed, <-errs)
+	assert.Equal(t, ErrorClosed, q.TryPush(4))
+	v, err := q.Pop()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+	values, err := q.PopBatch(5)
+	assert.Nil(t, err)
+	assert.Equal(t, []int{2}, values)
+	_, err = q.Pop()
+	assert.Equal(t, ErrorClosed, err)
+	_, err = q.TryPop()
+	assert.Equal(t, ErrorClosed, err)
+}
+
+func TestBlockingQueueProducerConsumer(t *testing.T) {
+	q := NewBlocking[int](16)
+	const producers = 4
+	const consumers = 4
+	const n = 1000
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				assert.Nil(t, q.Push(p*n+i))
+			}
+		}(p)
+	}
+
+	results := make(chan []int, consumers)
+	for c := 0; c < consumers; c++ {
+		go func() {
+			got := make([]int, 0)
+			for {
+				values, err := q.PopBatch(8)
+				if err != nil {
+					results <- got
+					return
+				}
+				got = append(got, values...)
+			}
+		}()
+	}
+	wg.Wait()
+	q.Close()
+
+	seen := make(map[int]bool)
+	for c := 0; c < consumers; c++ {
+		for _, v := range <-results {
+			assert.False(t, seen[v])
+			seen[v] = true
+		}
+	}
+	assert.Equal(t, producers*n, len(seen))
+}
@@ -0,0 +1,219 @@
+package queue
+
+import (
+	"sync/atomic"
+)
+
+// cacheLinePad is used to keep hot fields on different cache lines to avoid false sharing
+type cacheLinePad [64]byte
+
+// ringSlot is a slot of a ring queue, seq tells which turn the slot is in:
+// seq == pos means the slot is free for the producer at pos, and seq == pos+1 means it is filled for the consumer at pos
+type ringSlot[T any] struct {
+	seq   uint64
+	value T
+}
+
+// roundUpPowerOfTwo returns the least power of two which is not less than n and 2
+func roundUpPowerOfTwo(n int) int {
+	c := 2
+	for c < n {
+		c <<= 1
+	}
+	return c
+}
+
+func newRingSlots[T any](capacity int) []ringSlot[T] {
+	slots := make([]ringSlot[T], roundUpPowerOfTwo(capacity))
+	for i := range slots {
+		slots[i].seq = uint64(i)
+	}
+	return slots
+}
+
+// RingQueue is a bounded lock-free multi-producer multi-consumer queue based on Dmitry Vyukov's algorithm,
+// each slot has a sequence number so producers and consumers only contend on a CAS of their own position
+type RingQueue[T any] struct {
+	_          cacheLinePad
+	enqueuePos uint64
+	_          cacheLinePad
+	dequeuePos uint64
+	_          cacheLinePad
+	mask       uint64
+	slots      []ringSlot[T]
+}
+
+// NewRing creates a RingQueue, the capacity is rounded up to a power of two
+func NewRing[T any](capacity int) *RingQueue[T] {
+	slots := newRingSlots[T](capacity)
+	return &RingQueue[T]{
+		mask:  uint64(len(slots) - 1),
+		slots: slots,
+	}
+}
+
+// Offer pushes a value to the end of the queue, it returns false if the queue is full
+func (q *RingQueue[T]) Offer(value T) bool {
+	pos := atomic.LoadUint64(&q.enqueuePos)
+	for {
+		slot := &q.slots[pos&q.mask]
+		diff := int64(atomic.LoadUint64(&slot.seq) - pos)
+		if diff == 0 {
+			if atomic.CompareAndSwapUint64(&q.enqueuePos, pos, pos+1) {
+				slot.value = value
+				atomic.StoreUint64(&slot.seq, pos+1)
+				return true
+			}
+		} else if diff < 0 {
+			// the slot has not been consumed in the previous turn
+			return false
+		}
+		pos = atomic.LoadUint64(&q.enqueuePos)
+	}
+}
+
+// Poll removes the front value of the queue and returns it, it returns false if the queue is empty
+func (q *RingQueue[T]) Poll() (T, bool) {
+	pos := atomic.LoadUint64(&q.dequeuePos)
+	for {
+		slot := &q.slots[pos&q.mask]
+		diff := int64(atomic.LoadUint64(&slot.seq) - (pos + 1))
+		if diff == 0 {
+			if atomic.CompareAndSwapUint64(&q.dequeuePos, pos, pos+1) {
+				return q.take(slot, pos), true
+			}
+		} else if diff < 0 {
+			// the slot has not been filled in this turn
+			return *new(T), false
+		}
+		pos = atomic.LoadUint64(&q.dequeuePos)
+	}
+}
+
+// take takes the value from the slot at pos and frees the slot for the next turn
+func (q *RingQueue[T]) take(slot *ringSlot[T], pos uint64) T {
+	value := slot.value
+	slot.value = *new(T)
+	atomic.StoreUint64(&slot.seq, pos+q.mask+1)
+	return value
+}
+
+// Len returns the amount of values in the queue, it is only a snapshot while other goroutines are modifying the queue
+func (q *RingQueue[T]) Len() int {
+	return ringLen(atomic.LoadUint64(&q.enqueuePos), atomic.LoadUint64(&q.dequeuePos), len(q.slots))
+}
+
+// Cap returns the capacity of the queue
+func (q *RingQueue[T]) Cap() int {
+	return len(q.slots)
+}
+
+// MpscRingQueue is a RingQueue specialized for multiple producers and a single consumer,
+// Poll must not be called by more than one goroutine at the same time
+type MpscRingQueue[T any] struct {
+	q RingQueue[T]
+}
+
+// NewMpscRing creates a MpscRingQueue, the capacity is rounded up to a power of two
+func NewMpscRing[T any](capacity int) *MpscRingQueue[T] {
+	slots := newRingSlots[T](capacity)
+	return &MpscRingQueue[T]{
+		q: RingQueue[T]{
+			mask:  uint64(len(slots) - 1),
+			slots: slots,
+		},
+	}
+}
+
+// Offer pushes a value to the end of the queue, it returns false if the queue is full
+func (q *MpscRingQueue[T]) Offer(value T) bool {
+	return q.q.Offer(value)
+}
+
+// Poll removes the front value of the queue and returns it, it returns false if the queue is empty
+func (q *MpscRingQueue[T]) Poll() (T, bool) {
+	// only the consumer modifies dequeuePos, so no CAS is needed
+	pos := q.q.dequeuePos
+	slot := &q.q.slots[pos&q.q.mask]
+	if atomic.LoadUint64(&slot.seq) != pos+1 {
+		return *new(T), false
+	}
+	atomic.StoreUint64(&q.q.dequeuePos, pos+1)
+	return q.q.take(slot, pos), true
+}
+
+// Len returns the amount of values in the queue, it is only a snapshot while other goroutines are modifying the queue
+func (q *MpscRingQueue[T]) Len() int {
+	return q.q.Len()
+}
+
+// Cap returns the capacity of the queue
+func (q *MpscRingQueue[T]) Cap() int {
+	return q.q.Cap()
+}
+
+// SpscRingQueue is a bounded lock-free queue for a single producer and a single consumer,
+// Offer and Poll must not be called by more than one goroutine respectively
+type SpscRingQueue[T any] struct {
+	_      cacheLinePad
+	tail   uint64 // written by the producer
+	_      cacheLinePad
+	head   uint64 // written by the consumer
+	_      cacheLinePad
+	mask   uint64
+	buffer []T
+}
+
+// NewSpscRing creates a SpscRingQueue, the capacity is rounded up to a power of two
+func NewSpscRing[T any](capacity int) *SpscRingQueue[T] {
+	capacity = roundUpPowerOfTwo(capacity)
+	return &SpscRingQueue[T]{
+		mask:   uint64(capacity - 1),
+		buffer: make([]T, capacity),
+	}
+}
+
+// Offer pushes a value to the end of the queue, it returns false if the queue is full
+func (q *SpscRingQueue[T]) Offer(value T) bool {
+	tail := q.tail
+	if tail-atomic.LoadUint64(&q.head) == uint64(len(q.buffer)) {
+		return false
+	}
+	q.buffer[tail&q.mask] = value
+	atomic.StoreUint64(&q.tail, tail+1)
+	return true
+}
+
+// Poll removes the front value of the queue and returns it, it returns false if the queue is empty
+func (q *SpscRingQueue[T]) Poll() (T, bool) {
+	head := q.head
+	if head == atomic.LoadUint64(&q.tail) {
+		return *new(T), false
+	}
+	value := q.buffer[head&q.mask]
+	q.buffer[head&q.mask] = *new(T)
+	atomic.StoreUint64(&q.head, head+1)
+	return value, true
+}
+
+// Len returns the amount of values in the queue, it is only a snapshot while other goroutines are modifying the queue
+func (q *SpscRingQueue[T]) Len() int {
+	return ringLen(atomic.LoadUint64(&q.tail), atomic.LoadUint64(&q.head), len(q.buffer))
+}
+
+// Cap returns the capacity of the queue
+func (q *SpscRingQueue[T]) Cap() int {
+	return len(q.buffer)
+}
+
+// ringLen returns tail-head clamped to [0, capacity], the positions may be loaded at different moments
+func ringLen(tail, head uint64, capacity int) int {
+	n := int64(tail - head)
+	if n < 0 {
+		return 0
+	}
+	if n > int64(capacity) {
+		return capacity
+	}
+	return int(n)
+}
@@ -0,0 +1,243 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ringQueue is implemented by RingQueue, MpscRingQueue and SpscRingQueue
+type ringQueue[T any] interface {
+	Offer(value T) bool
+	Poll() (T, bool)
+	Len() int
+	Cap() int
+}
+
+func TestRingQueue(t *testing.T) {
+	q := NewRing[int](5)
+	assert.Equal(t, 8, q.Cap())
+	_, ok := q.Poll()
+	assert.False(t, ok)
+
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 8; i++ {
+			assert.True(t, q.Offer(i))
+		}
+		assert.False(t, q.Offer(8))
+		assert.Equal(t, 8, q.Len())
+		for i := 0; i < 8; i++ {
+			v, ok := q.Poll()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		_, ok = q.Poll()
+		assert.False(t, ok)
+		assert.Equal(t, 0, q.Len())
+	}
+	assert.Equal(t, 2, NewRing[int](0).Cap())
+}
+
+func TestMpscRingQueue(t *testing.T) {
+	q := NewMpscRing[int](5)
+	assert.Equal(t, 8, q.Cap())
+	_, ok := q.Poll()
+	assert.False(t, ok)
+
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 8; i++ {
+			assert.True(t, q.Offer(i))
+		}
+		assert.False(t, q.Offer(8))
+		assert.Equal(t, 8, q.Len())
+		for i := 0; i < 8; i++ {
+			v, ok := q.Poll()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		_, ok = q.Poll()
+		assert.False(t, ok)
+		assert.Equal(t, 0, q.Len())
+	}
+	assert.Equal(t, 2, NewMpscRing[int](0).Cap())
+}
+
+func TestSpscRingQueue(t *testing.T) {
+	q := NewSpscRing[int](5)
+	assert.Equal(t, 8, q.Cap())
+	_, ok := q.Poll()
+	assert.False(t, ok)
+
+	for round := 0; round < 3; round++ {
+		for i := 0; i < 8; i++ {
+			assert.True(t, q.Offer(i))
+		}
+		assert.False(t, q.Offer(8))
+		assert.Equal(t, 8, q.Len())
+		for i := 0; i < 8; i++ {
+			v, ok := q.Poll()
+			assert.True(t, ok)
+			assert.Equal(t, i, v)
+		}
+		_, ok = q.Poll()
+		assert.False(t, ok)
+		assert.Equal(t, 0, q.Len())
+	}
+	assert.Equal(t, 2, NewSpscRing[int](0).Cap())
+}
+
+// testRingQueueConcurrent runs producers and consumers on q, and checks each value is received exactly once
+func testRingQueueConcurrent(t *testing.T, q ringQueue[int], producers, consumers int) {
+	const n = 20000
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < n; {
+				if q.Offer(p*n + i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	total := producers * n
+	var mutex sync.Mutex
+	received := make([]bool, total)
+	count := 0
+	var cwg sync.WaitGroup
+	for c := 0; c < consumers; c++ {
+		cwg.Add(1)
+		go func() {
+			defer cwg.Done()
+			last := make(map[int]int)
+			for {
+				mutex.Lock()
+				done := count == total
+				mutex.Unlock()
+				if done {
+					return
+				}
+				v, ok := q.Poll()
+				if !ok {
+					runtime.Gosched()
+					continue
+				}
+				// values from the same producer are received in order
+				p := v / n
+				if prev, ok := last[p]; ok {
+					assert.Less(t, prev, v)
+				}
+				last[p] = v
+				mutex.Lock()
+				assert.False(t, received[v])
+				received[v] = true
+				count++
+				mutex.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	cwg.Wait()
+	assert.Equal(t, 0, q.Len())
+}
+
+func TestRingQueueConcurrent(t *testing.T) {
+	testRingQueueConcurrent(t, NewRing[int](64), 4, 4)
+}
+
+func TestMpscRingQueueConcurrent(t *testing.T) {
+	testRingQueueConcurrent(t, NewMpscRing[int](64), 4, 1)
+}
+
+func TestSpscRingQueueConcurrent(t *testing.T) {
+	testRingQueueConcurrent(t, NewSpscRing[int](64), 1, 1)
+}
+
+func benchmarkOfferPoll(b *testing.B, offer func(int) bool, poll func() bool) {
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			if i%2 == 0 {
+				offer(i)
+			} else {
+				poll()
+			}
+			i++
+		}
+	})
+}
+
+func BenchmarkRingQueue(b *testing.B) {
+	q := NewRing[int](1024)
+	benchmarkOfferPoll(b, q.Offer, func() bool {
+		_, ok := q.Poll()
+		return ok
+	})
+}
+
+func BenchmarkQueueWithGoroutineSafe(b *testing.B) {
+	q := New[int](WithGoroutineSafe[int]())
+	var mutex sync.Mutex
+	benchmarkOfferPoll(b, func(v int) bool {
+		q.Push(v)
+		return true
+	}, func() bool {
+		// Queue.Pop panics on empty queue, so check and pop atomically
+		mutex.Lock()
+		defer mutex.Unlock()
+		if q.Empty() {
+			return false
+		}
+		q.Pop()
+		return true
+	})
+}
+
+func BenchmarkSpscRingQueue(b *testing.B) {
+	q := NewSpscRing[int](1024)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < b.N; {
+			if _, ok := q.Poll(); ok {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+		close(done)
+	}()
+	for i := 0; i < b.N; {
+		if q.Offer(i) {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	<-done
+}
+
+func BenchmarkSpscQueueWithGoroutineSafe(b *testing.B) {
+	q := New[int](WithGoroutineSafe[int]())
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < b.N; {
+			if q.Size() > 0 {
+				q.Pop()
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+		close(done)
+	}()
+	for i := 0; i < b.N; i++ {
+		q.Push(i)
+	}
+	<-done
+}
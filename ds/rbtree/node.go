@@ -15,6 +15,7 @@ type Node[K, V any] struct {
 	left   *Node[K, V]
 	right  *Node[K, V]
 	color  Color
+	size   int
 	key    K
 	value  V
 }
@@ -34,6 +35,11 @@ func (n *Node[K, V]) SetValue(val V) {
 	n.value = val
 }
 
+// Size returns the amount of nodes in the subtree rooted at the Node
+func (n *Node[K, V]) Size() int {
+	return n.size
+}
+
 // Next returns the Node's successor as an iterator.
 func (n *Node[K, V]) Next() *Node[K, V] {
 	return successor(n)
@@ -89,3 +95,11 @@ func maximum[K any, V any](n *Node[K, V]) *Node[K, V] {
 	}
 	return n
 }
+
+// getSize returns the size of subtree n, the size of a nil subtree is 0
+func getSize[K, V any](n *Node[K, V]) int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
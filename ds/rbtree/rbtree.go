@@ -25,6 +25,31 @@ func New[K, V any](cmp comparator.Comparator[K]) *RbTree[K, V] {
 	return &RbTree[K, V]{keyCmp: cmp}
 }
 
+// BuildFromSorted rebuilds the RbTree with the passed keys and values in O(n) time,
+// the keys must be sorted in ascending order and have the same length as the values
+func (t *RbTree[K, V]) BuildFromSorted(keys []K, values []V) {
+	redDepth := 0 // floor(log2(n)), the nodes at the deepest level are red
+	for n := len(keys); n > 1; n >>= 1 {
+		redDepth++
+	}
+	t.root = buildSorted(keys, values, nil, 0, redDepth)
+	t.size = len(keys)
+}
+
+func buildSorted[K, V any](keys []K, values []V, parent *Node[K, V], depth, redDepth int) *Node[K, V] {
+	if len(keys) == 0 {
+		return nil
+	}
+	mid := len(keys) / 2
+	n := &Node[K, V]{parent: parent, color: BLACK, size: len(keys), key: keys[mid], value: values[mid]}
+	if depth == redDepth && depth > 0 {
+		n.color = RED
+	}
+	n.left = buildSorted(keys[:mid], values[:mid], n, depth+1, redDepth)
+	n.right = buildSorted(keys[mid+1:], values[mid+1:], n, depth+1, redDepth)
+	return n
+}
+
 // Clear clears the RbTree
 func (t *RbTree[K, V]) Clear() {
 	t.root = nil
@@ -103,6 +128,7 @@ func (t *RbTree[K, V]) Insert(key K, value V) {
 
 	for x != nil {
 		y = x
+		x.size++
 		if t.keyCmp(key, x.key) < 0 {
 			x = x.left
 		} else {
@@ -110,7 +136,7 @@ func (t *RbTree[K, V]) Insert(key K, value V) {
 		}
 	}
 
-	z := &Node[K, V]{parent: y, color: RED, key: key, value: value}
+	z := &Node[K, V]{parent: y, color: RED, size: 1, key: key, value: value}
 	t.size++
 
 	if y == nil {
@@ -197,15 +223,45 @@ func (t *RbTree[K, V]) Delete(node *Node[K, V]) {
 		y.parent.right = x
 	}
 
+	for p := xparent; p != nil; p = p.parent {
+		p.size--
+	}
+
+	color := y.color
 	if y != z {
-		z.key = y.key
-		z.value = y.value
+		// move the successor y into the place of z instead of copying its key and value into z,
+		// so that the other nodes stay valid after z is deleted
+		if xparent == z {
+			xparent = y
+		}
+		t.replace(z, y)
 	}
 
-	if y.color {
+	if color {
 		t.rbDeleteFixup(x, xparent)
 	}
 	t.size--
+	// a node in the tree has size 1 at least, so size 0 marks z as deleted
+	z.size = 0
+}
+
+// replace puts y at the place of z in the tree, y takes z's children, color and size
+func (t *RbTree[K, V]) replace(z, y *Node[K, V]) {
+	y.parent, y.left, y.right = z.parent, z.left, z.right
+	y.color, y.size = z.color, z.size
+	if y.left != nil {
+		y.left.parent = y
+	}
+	if y.right != nil {
+		y.right.parent = y
+	}
+	if z.parent == nil {
+		t.root = y
+	} else if z == z.parent.left {
+		z.parent.left = y
+	} else {
+		z.parent.right = y
+	}
 }
 
 func (t *RbTree[K, V]) rbDeleteFixup(x, parent *Node[K, V]) {
@@ -303,6 +359,8 @@ func (t *RbTree[K, V]) leftRotate(x *Node[K, V]) {
 	}
 	y.left = x
 	x.parent = y
+	y.size = x.size
+	x.size = getSize(x.left) + getSize(x.right) + 1
 }
 
 func (t *RbTree[K, V]) rightRotate(x *Node[K, V]) {
@@ -321,6 +379,8 @@ func (t *RbTree[K, V]) rightRotate(x *Node[K, V]) {
 	}
 	y.right = x
 	x.parent = y
+	y.size = x.size
+	x.size = getSize(x.left) + getSize(x.right) + 1
 }
 
 // findNode finds the node that its key is equal to the passed key, and returns it.
@@ -395,12 +455,86 @@ func (t *RbTree[K, V]) findUpperBoundNode(x *Node[K, V], key K) *Node[K, V] {
 	return x
 }
 
-// Traversal traversals elements in the RbTree, it will not stop until to the end of RbTree or the visitor returns false
+// Select finds the node with the k-th smallest key (counting from 0), and returns it.
+// It returns nil if k is out of range
+func (t *RbTree[K, V]) Select(k int) *Node[K, V] {
+	if k < 0 || k >= t.size {
+		return nil
+	}
+	x := t.root
+	for x != nil {
+		leftSize := getSize(x.left)
+		if k < leftSize {
+			x = x.left
+		} else if k > leftSize {
+			k -= leftSize + 1
+			x = x.right
+		} else {
+			return x
+		}
+	}
+	return nil
+}
+
+// Rank returns the amount of nodes that their keys are less than the passed key
+func (t *RbTree[K, V]) Rank(key K) int {
+	rank := 0
+	x := t.root
+	for x != nil {
+		if t.keyCmp(key, x.key) <= 0 {
+			x = x.left
+		} else {
+			rank += getSize(x.left) + 1
+			x = x.right
+		}
+	}
+	return rank
+}
+
+// DeleteRange deletes the nodes that their keys are in range [lo, hi), and returns the amount of deleted nodes
+func (t *RbTree[K, V]) DeleteRange(lo, hi K) int {
+	count := 0
+	node := t.FindLowerBoundNode(lo)
+	for node != nil && t.keyCmp(node.key, hi) < 0 {
+		next := node.Next()
+		t.Delete(node)
+		node = next
+		count++
+	}
+	return count
+}
+
+// RangeTraversal traversals elements that their keys are in range [lo, hi) in the RbTree,
+// it will not stop until to the end of the range or the visitor returns false.
+// The visitor may delete nodes of the RbTree, the deleted nodes which are not visited yet will not be visited
+func (t *RbTree[K, V]) RangeTraversal(lo, hi K, visitor visitor.KvVisitor[K, V]) {
+	t.walk(t.FindLowerBoundNode(lo), func(key K, value V) bool {
+		return t.keyCmp(key, hi) < 0 && visitor(key, value)
+	})
+}
+
+// Traversal traversals elements in the RbTree, it will not stop until to the end of RbTree or the visitor returns false.
+// The visitor may delete nodes of the RbTree, the deleted nodes which are not visited yet will not be visited
 func (t *RbTree[K, V]) Traversal(visitor visitor.KvVisitor[K, V]) {
-	for node := t.First(); node != nil; node = node.Next() {
+	t.walk(t.First(), visitor)
+}
+
+// walk calls visitor with node and the nodes after it in order, until to the end or the visitor returns false
+func (t *RbTree[K, V]) walk(node *Node[K, V], visitor visitor.KvVisitor[K, V]) {
+	for node != nil {
+		next := node.Next()
 		if !visitor(node.key, node.value) {
-			break
+			return
 		}
+		switch {
+		case node.size > 0:
+			// node is still in the tree, the nodes after it may be deleted
+			next = node.Next()
+		case next != nil && next.size == 0:
+			// both node and next are deleted, resume at the first node whose key is greater than node's
+			next = t.FindUpperBoundNode(node.key)
+		}
+		node = next
 	}
 }
 
@@ -412,6 +546,7 @@ func (t *RbTree[K, V]) IsRbTree() (bool, error) {
 	// 3. All leaves (NIL) are black.
 	// 4. If a node is red, then both its children are black.
 	// 5. Every path from a given node to any of its descendant NIL nodes contains the same number of black nodes.
+	// Besides, every node's size must be equal to the amount of nodes in its subtree (property 6).
 	_, property, ok := t.test(t.root)
 	if !ok {
 		return false, fmt.Errorf("violate property %v", property)
@@ -440,6 +575,10 @@ func (t *RbTree[K, V]) test(n *Node[K, V]) (int, int, bool) {
 	if rightBlackCount != leftBlackCount { // property 5:
 		return leftBlackCount, 5, false
 	}
+
+	if n.size != getSize(n.left)+getSize(n.right)+1 { // subtree size
+		return 0, 6, false
+	}
 	blackCount := leftBlackCount
 
 	if !n.color {
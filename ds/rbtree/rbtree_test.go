@@ -186,3 +186,122 @@ func TestRbTreeCompare(t *testing.T) {
 	assert.Equal(t, -1, tree.Compare(1, 2))
 	assert.Equal(t, 1, tree.Compare(2, 1))
 }
+
+func TestRbTreeSelectRank(t *testing.T) {
+	tree := New[int, int](comparator.IntComparator)
+	assert.Nil(t, tree.Select(0))
+	assert.Equal(t, 0, tree.Rank(5))
+
+	keys := rand.Perm(1000)
+	for _, k := range keys {
+		tree.Insert(k*2, k)
+	}
+	for i := 0; i < 1000; i++ {
+		n := tree.Select(i)
+		assert.Equal(t, i*2, n.Key())
+		assert.Equal(t, i, tree.Rank(i*2))
+		assert.Equal(t, i+1, tree.Rank(i*2+1))
+	}
+	assert.Nil(t, tree.Select(-1))
+	assert.Nil(t, tree.Select(1000))
+
+	for _, k := range keys[:500] {
+		tree.Delete(tree.FindNode(k * 2))
+		b, _ := tree.IsRbTree()
+		assert.True(t, b)
+	}
+	i := 0
+	for n := tree.First(); n != nil; n = n.Next() {
+		assert.Equal(t, n, tree.Select(i))
+		assert.Equal(t, i, tree.Rank(n.Key()))
+		i++
+	}
+	assert.Equal(t, tree.Size(), tree.root.Size())
+}
+
+func TestRbTreeDeleteRange(t *testing.T) {
+	tree := New[int, int](comparator.IntComparator)
+	for _, k := range rand.Perm(1000) {
+		tree.Insert(k, k)
+		tree.Insert(k, k+1)
+	}
+	assert.Equal(t, 0, tree.DeleteRange(500, 500))
+	assert.Equal(t, 600, tree.DeleteRange(100, 400))
+	assert.Equal(t, 1400, tree.Size())
+	b, _ := tree.IsRbTree()
+	assert.True(t, b)
+
+	count := 0
+	tree.RangeTraversal(0, 1000, func(key, value int) bool {
+		assert.False(t, key >= 100 && key < 400)
+		count++
+		return true
+	})
+	assert.Equal(t, 1400, count)
+
+	assert.Equal(t, 1400, tree.DeleteRange(-1, 1000))
+	assert.True(t, tree.Empty())
+}
+
+func TestRbTreeDeleteWhileTraversal(t *testing.T) {
+	tree := New[int, int](comparator.IntComparator)
+	for _, k := range rand.Perm(1000) {
+		tree.Insert(k, k)
+	}
+	// nodes with two children keep valid after deleted
+	var visited []int
+	tree.RangeTraversal(100, 900, func(key, value int) bool {
+		visited = append(visited, key)
+		if key%2 == 0 {
+			tree.Delete(tree.FindNode(key))
+		}
+		return true
+	})
+	assert.Equal(t, 800, len(visited))
+	for i, key := range visited {
+		assert.Equal(t, 100+i, key)
+	}
+	assert.Equal(t, 600, tree.Size())
+	b, _ := tree.IsRbTree()
+	assert.True(t, b)
+
+	// delete the visited node and the next nodes
+	visited = visited[:0]
+	tree.Traversal(func(key, value int) bool {
+		visited = append(visited, key)
+		for k := key; k < key+3; k++ {
+			tree.Delete(tree.FindNode(k))
+		}
+		return true
+	})
+	assert.Equal(t, []int{0, 3, 6, 9}, visited[:4])
+	assert.True(t, tree.Empty())
+}
+
+func TestRbTreeBuildFromSorted(t *testing.T) {
+	tree := New[int, int](comparator.IntComparator)
+	for n := 0; n < 300; n++ {
+		keys := make([]int, n)
+		values := make([]int, n)
+		for i := 0; i < n; i++ {
+			keys[i] = i / 2
+			values[i] = i
+		}
+		tree.BuildFromSorted(keys, values)
+		b, err := tree.IsRbTree()
+		assert.True(t, b, err)
+		assert.Equal(t, n, tree.Size())
+
+		i := 0
+		for node := tree.First(); node != nil; node = node.Next() {
+			assert.Equal(t, i, node.Value())
+			i++
+		}
+		assert.Equal(t, n, i)
+	}
+
+	tree.Insert(1000, 1000)
+	tree.Delete(tree.FindNode(0))
+	b, _ := tree.IsRbTree()
+	assert.True(t, b)
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package ringbuffer
+
+import "iter"
+
+// All returns an iterator over index-value pairs in the ring buffer, from front to back
+func (r *RingBuffer[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(i, r.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs in the ring buffer, from back to front
+func (r *RingBuffer[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := r.size - 1; i >= 0; i-- {
+			if !yield(i, r.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the ring buffer, from front to back
+func (r *RingBuffer[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < r.size; i++ {
+			if !yield(r.At(i)) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package ringbuffer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBufferIterSeq(t *testing.T) {
+	r := New[int](3, WithFullPolicy(OverwriteOldest))
+	for i := 0; i < 5; i++ {
+		r.PushBack(i)
+	}
+
+	values := make([]int, 0)
+	for i, v := range r.All() {
+		assert.Equal(t, r.At(i), v)
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{2, 3, 4}, values)
+
+	values = values[:0]
+	for _, v := range r.Backward() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{4, 3, 2}, values)
+
+	values = values[:0]
+	for v := range r.Values() {
+		if v == 4 {
+			break
+		}
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{2, 3}, values)
+}
@@ -0,0 +1,68 @@
+package ringbuffer
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+var _ iterator.RandomAccessIterator[int] = (*RingBufferIterator[int])(nil)
+
+// RingBufferIterator is an implementation of RingBuffer iterator
+type RingBufferIterator[T any] struct {
+	rb       *RingBuffer[T]
+	position int
+}
+
+// IsValid returns true if the iterator is valid, otherwise returns false
+func (iter *RingBufferIterator[T]) IsValid() bool {
+	return iter.position >= 0 && iter.position < iter.rb.Size()
+}
+
+// Value returns the value of the ring buffer at the position of the iterator point to
+func (iter *RingBufferIterator[T]) Value() T {
+	return iter.rb.At(iter.position)
+}
+
+// SetValue sets the value of the ring buffer at the position of the iterator point to
+func (iter *RingBufferIterator[T]) SetValue(val T) {
+	iter.rb.Set(iter.position, val)
+}
+
+// Next moves the position of the iterator to the next position and returns itself
+func (iter *RingBufferIterator[T]) Next() iterator.ConstIterator[T] {
+	if iter.position < iter.rb.Size() {
+		iter.position++
+	}
+	return iter
+}
+
+// Prev moves the position of the iterator to the previous position and returns itself
+func (iter *RingBufferIterator[T]) Prev() iterator.ConstBidIterator[T] {
+	if iter.position >= 0 {
+		iter.position--
+	}
+	return iter
+}
+
+// Clone clones the iterator to a new iterator
+func (iter *RingBufferIterator[T]) Clone() iterator.ConstIterator[T] {
+	return &RingBufferIterator[T]{rb: iter.rb, position: iter.position}
+}
+
+// IteratorAt creates a new iterator with the passed position
+func (iter *RingBufferIterator[T]) IteratorAt(position int) iterator.RandomAccessIterator[T] {
+	return &RingBufferIterator[T]{rb: iter.rb, position: position}
+}
+
+// Position returns the position of iterator
+func (iter *RingBufferIterator[T]) Position() int {
+	return iter.position
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *RingBufferIterator[T]) Equal(other iterator.ConstIterator[T]) bool {
+	otherIter, ok := other.(*RingBufferIterator[T])
+	if !ok {
+		return false
+	}
+	return otherIter.rb == iter.rb && otherIter.position == iter.position
+}
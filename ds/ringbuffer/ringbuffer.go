@@ -0,0 +1,233 @@
+package ringbuffer
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Define internal errors
+var (
+	ErrOutOfRange = errors.New("out off range")
+	ErrFull       = errors.New("ring buffer is full")
+)
+
+// FullPolicy decides what to do when pushing to a full RingBuffer
+type FullPolicy int
+
+// Policies when the RingBuffer is full
+const (
+	// RejectWhenFull rejects the pushed value with ErrFull
+	RejectWhenFull FullPolicy = iota
+	// OverwriteOldest drops the value at the opposite end to make room for the pushed value
+	OverwriteOldest
+)
+
+// Options holds the RingBuffer's options
+type Options struct {
+	policy FullPolicy
+}
+
+// Option is a function type used to set Options
+type Option func(option *Options)
+
+// WithFullPolicy sets the policy used when pushing to a full RingBuffer, the default policy is RejectWhenFull
+func WithFullPolicy(policy FullPolicy) Option {
+	return func(option *Options) {
+		option.policy = policy
+	}
+}
+
+// RingBuffer is a fixed-capacity circular buffer supports insertion and removal at both ends, random access and iterator access
+type RingBuffer[T any] struct {
+	buf    []T
+	head   int
+	size   int
+	policy FullPolicy
+}
+
+// New creates a RingBuffer holding at most capacity values, capacity less than 1 is treated as 1
+func New[T any](capacity int, opts ...Option) *RingBuffer[T] {
+	option := Options{}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &RingBuffer[T]{
+		buf:    make([]T, capacity),
+		policy: option.policy,
+	}
+}
+
+// Size returns the amount of values in the ring buffer
+func (r *RingBuffer[T]) Size() int {
+	return r.size
+}
+
+// Capacity returns the capacity of the ring buffer
+func (r *RingBuffer[T]) Capacity() int {
+	return len(r.buf)
+}
+
+// Empty returns true if the ring buffer is empty, otherwise returns false
+func (r *RingBuffer[T]) Empty() bool {
+	return r.size == 0
+}
+
+// Full returns true if the ring buffer is full, otherwise returns false
+func (r *RingBuffer[T]) Full() bool {
+	return r.size == len(r.buf)
+}
+
+// PushBack pushes a value to the back of the ring buffer. If the ring buffer is full, it returns ErrFull
+// with the RejectWhenFull policy, or drops the front value with the OverwriteOldest policy
+func (r *RingBuffer[T]) PushBack(value T) error {
+	if r.Full() {
+		if r.policy != OverwriteOldest {
+			return ErrFull
+		}
+		r.PopFront()
+	}
+	r.buf[r.index(r.size)] = value
+	r.size++
+	return nil
+}
+
+// PushFront pushes a value to the front of the ring buffer. If the ring buffer is full, it returns ErrFull
+// with the RejectWhenFull policy, or drops the back value with the OverwriteOldest policy
+func (r *RingBuffer[T]) PushFront(value T) error {
+	if r.Full() {
+		if r.policy != OverwriteOldest {
+			return ErrFull
+		}
+		r.PopBack()
+	}
+	r.head = r.index(len(r.buf) - 1)
+	r.buf[r.head] = value
+	r.size++
+	return nil
+}
+
+// PopFront returns the value at the first position of the ring buffer and removes it
+func (r *RingBuffer[T]) PopFront() T {
+	if r.size == 0 {
+		panic("ring buffer is empty")
+	}
+	v := r.buf[r.head]
+	r.buf[r.head] = *new(T)
+	r.head = r.index(1)
+	r.size--
+	return v
+}
+
+// PopBack returns the value at the last position of the ring buffer and removes it
+func (r *RingBuffer[T]) PopBack() T {
+	if r.size == 0 {
+		panic("ring buffer is empty")
+	}
+	i := r.index(r.size - 1)
+	v := r.buf[i]
+	r.buf[i] = *new(T)
+	r.size--
+	return v
+}
+
+// Front returns the value at the first position of the ring buffer
+func (r *RingBuffer[T]) Front() T {
+	return r.At(0)
+}
+
+// Back returns the value at the last position of the ring buffer
+func (r *RingBuffer[T]) Back() T {
+	return r.At(r.size - 1)
+}
+
+// At returns the value at position pos of the ring buffer
+func (r *RingBuffer[T]) At(pos int) T {
+	if pos < 0 || pos >= r.size {
+		panic("out off range")
+	}
+	return r.buf[r.index(pos)]
+}
+
+// Set sets the value of the ring buffer's position pos with value val
+func (r *RingBuffer[T]) Set(pos int, val T) error {
+	if pos < 0 || pos >= r.size {
+		return ErrOutOfRange
+	}
+	r.buf[r.index(pos)] = val
+	return nil
+}
+
+// Slices returns the values of the ring buffer as two contiguous parts without copying, the values are the
+// concatenation of the two parts from front to back. The second part is empty if the values are not wrapped.
+// The parts share the memory with the ring buffer, so they are only valid until the next modification
+func (r *RingBuffer[T]) Slices() ([]T, []T) {
+	end := r.head + r.size
+	if end <= len(r.buf) {
+		return r.buf[r.head:end:end], r.buf[:0:0]
+	}
+	end -= len(r.buf)
+	return r.buf[r.head:], r.buf[:end:end]
+}
+
+// Clear clears all values in the ring buffer
+func (r *RingBuffer[T]) Clear() {
+	first, second := r.Slices()
+	for i := range first {
+		first[i] = *new(T)
+	}
+	for i := range second {
+		second[i] = *new(T)
+	}
+	r.head = 0
+	r.size = 0
+}
+
+// String returns a string representation of the ring buffer
+func (r *RingBuffer[T]) String() string {
+	str := "["
+	for i := 0; i < r.size; i++ {
+		if i > 0 {
+			str += " "
+		}
+		str += fmt.Sprintf("%v", r.At(i))
+	}
+	str += "]"
+	return str
+}
+
+// Begin returns an iterator of the ring buffer with the first position
+func (r *RingBuffer[T]) Begin() *RingBufferIterator[T] {
+	return r.First()
+}
+
+// End returns an iterator of the ring buffer with the position r.Size()
+func (r *RingBuffer[T]) End() *RingBufferIterator[T] {
+	return r.IterAt(r.size)
+}
+
+// First returns an iterator of the ring buffer with the first position
+func (r *RingBuffer[T]) First() *RingBufferIterator[T] {
+	return r.IterAt(0)
+}
+
+// Last returns an iterator of the ring buffer with the last position
+func (r *RingBuffer[T]) Last() *RingBufferIterator[T] {
+	return r.IterAt(r.size - 1)
+}
+
+// IterAt returns an iterator of the ring buffer with the position pos
+func (r *RingBuffer[T]) IterAt(pos int) *RingBufferIterator[T] {
+	return &RingBufferIterator[T]{rb: r, position: pos}
+}
+
+// index returns the index in buf of the position pos
+func (r *RingBuffer[T]) index(pos int) int {
+	i := r.head + pos
+	if i >= len(r.buf) {
+		i -= len(r.buf)
+	}
+	return i
+}
@@ -0,0 +1,108 @@
+package ringbuffer
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/liyue201/gostl/algorithm/sort"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRingBuffer(t *testing.T) {
+	r := New[int](4)
+	assert.True(t, r.Empty())
+	assert.Equal(t, 4, r.Capacity())
+	assert.Panics(t, func() { r.PopFront() })
+	assert.Panics(t, func() { r.PopBack() })
+
+	assert.Nil(t, r.PushBack(1))  // [1]
+	assert.Nil(t, r.PushFront(2)) // [2 1]
+	assert.Nil(t, r.PushBack(3))  // [2 1 3]
+	assert.Nil(t, r.PushFront(4)) // [4 2 1 3]
+	assert.True(t, r.Full())
+	assert.Equal(t, ErrFull, r.PushBack(5))
+	assert.Equal(t, ErrFull, r.PushFront(5))
+	assert.Equal(t, "[4 2 1 3]", r.String())
+	assert.Equal(t, 4, r.Front())
+	assert.Equal(t, 3, r.Back())
+	assert.Equal(t, 1, r.At(2))
+	assert.Panics(t, func() { r.At(4) })
+
+	assert.Nil(t, r.Set(1, 7))
+	assert.Equal(t, ErrOutOfRange, r.Set(4, 7))
+	assert.Equal(t, "[4 7 1 3]", r.String())
+
+	assert.Equal(t, 4, r.PopFront())
+	assert.Equal(t, 3, r.PopBack())
+	assert.Equal(t, 2, r.Size())
+	r.Clear()
+	assert.True(t, r.Empty())
+	assert.Equal(t, "[]", r.String())
+}
+
+func TestRingBufferOverwrite(t *testing.T) {
+	r := New[int](3, WithFullPolicy(OverwriteOldest))
+	for i := 0; i < 10; i++ {
+		assert.Nil(t, r.PushBack(i))
+	}
+	assert.Equal(t, "[7 8 9]", r.String())
+	assert.Nil(t, r.PushFront(6))
+	assert.Equal(t, "[6 7 8]", r.String())
+}
+
+func TestRingBufferSlices(t *testing.T) {
+	r := New[int](5)
+	first, second := r.Slices()
+	assert.Empty(t, first)
+	assert.Empty(t, second)
+
+	for i := 0; i < 4; i++ {
+		r.PushBack(i)
+	}
+	first, second = r.Slices()
+	assert.Equal(t, []int{0, 1, 2, 3}, first)
+	assert.Empty(t, second)
+
+	r.PopFront()
+	r.PopFront()
+	r.PushBack(4)
+	r.PushBack(5)
+	r.PushBack(6)
+	first, second = r.Slices()
+	assert.Equal(t, []int{2, 3, 4}, first)
+	assert.Equal(t, []int{5, 6}, second)
+
+	// the slices share memory with the ring buffer
+	first[0] = 10
+	assert.Equal(t, 10, r.Front())
+	// appending to a part doesn't overwrite the other values
+	_ = append(second, 100)
+	assert.Equal(t, 10, r.Front())
+}
+
+func TestRingBufferIterator(t *testing.T) {
+	r := New[int](10, WithFullPolicy(OverwriteOldest))
+	for i := 0; i < 15; i++ {
+		r.PushBack(rand.Intn(100))
+	}
+	sort.Sort[int](r.Begin(), r.End(), comparator.IntComparator)
+	for i := 1; i < r.Size(); i++ {
+		assert.LessOrEqual(t, r.At(i-1), r.At(i))
+	}
+
+	i := 0
+	for iter := r.First(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, r.At(i), iter.Value())
+		iter.SetValue(i)
+		i++
+	}
+	assert.Equal(t, 10, i)
+	for iter := r.Last(); iter.IsValid(); iter.Prev() {
+		i--
+		assert.Equal(t, i, iter.Value())
+	}
+	assert.True(t, r.Begin().Equal(r.First()))
+	assert.False(t, r.Begin().Equal(r.End()))
+	assert.Equal(t, 3, r.First().IteratorAt(3).Value())
+}
@@ -0,0 +1,459 @@
+package roaring
+
+import (
+	"math/bits"
+	"sort"
+)
+
+// Constants of containers
+const (
+	arrayMaxSize  = 4096 // an array container holds at most arrayMaxSize values
+	bitmapWords   = 1024 // a bitmap container holds 65536 bits
+	bitmapBytes   = bitmapWords * 8
+	maxLowValue   = 1<<16 - 1
+	containerSize = 1 << 16
+)
+
+// container holds the low 16 bits of the values sharing the same high 16 bits
+type container interface {
+	// add adds x and returns the container, which may be converted to another kind
+	add(x uint16) container
+	// remove removes x and returns the container, which may be converted to another kind
+	remove(x uint16) container
+	contains(x uint16) bool
+	cardinality() int
+	// next returns the smallest value >= x
+	next(x uint16) (uint16, bool)
+	// traversal calls fn with the values in ascending order, it returns false if fn returns false
+	traversal(fn func(x uint16) bool) bool
+	// asBitmap returns the container as a bitmap container, the result must not be modified
+	asBitmap() *bitmapContainer
+	clone() container
+	numRuns() int
+}
+
+// arrayContainer is a sorted array of values, it is used for sparse containers
+type arrayContainer struct {
+	values []uint16
+}
+
+func newArrayContainer(values ...uint16) *arrayContainer {
+	return &arrayContainer{values: values}
+}
+
+func (c *arrayContainer) search(x uint16) int {
+	return sort.Search(len(c.values), func(i int) bool { return c.values[i] >= x })
+}
+
+func (c *arrayContainer) add(x uint16) container {
+	i := c.search(x)
+	if i < len(c.values) && c.values[i] == x {
+		return c
+	}
+	if len(c.values) >= arrayMaxSize {
+		b := c.toBitmap()
+		b.add(x)
+		return b
+	}
+	c.values = append(c.values, 0)
+	copy(c.values[i+1:], c.values[i:])
+	c.values[i] = x
+	return c
+}
+
+func (c *arrayContainer) remove(x uint16) container {
+	i := c.search(x)
+	if i < len(c.values) && c.values[i] == x {
+		c.values = append(c.values[:i], c.values[i+1:]...)
+	}
+	return c
+}
+
+func (c *arrayContainer) contains(x uint16) bool {
+	i := c.search(x)
+	return i < len(c.values) && c.values[i] == x
+}
+
+func (c *arrayContainer) cardinality() int {
+	return len(c.values)
+}
+
+func (c *arrayContainer) next(x uint16) (uint16, bool) {
+	i := c.search(x)
+	if i < len(c.values) {
+		return c.values[i], true
+	}
+	return 0, false
+}
+
+func (c *arrayContainer) traversal(fn func(x uint16) bool) bool {
+	for _, v := range c.values {
+		if !fn(v) {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *arrayContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, v := range c.values {
+		b.words[v/64] |= 1 << (v % 64)
+	}
+	b.card = len(c.values)
+	return b
+}
+
+func (c *arrayContainer) asBitmap() *bitmapContainer {
+	return c.toBitmap()
+}
+
+func (c *arrayContainer) clone() container {
+	values := make([]uint16, len(c.values))
+	copy(values, c.values)
+	return &arrayContainer{values: values}
+}
+
+func (c *arrayContainer) numRuns() int {
+	if len(c.values) == 0 {
+		return 0
+	}
+	n := 1
+	for i := 1; i < len(c.values); i++ {
+		if c.values[i] != c.values[i-1]+1 {
+			n++
+		}
+	}
+	return n
+}
+
+// bitmapContainer is a bitset of 65536 bits, it is used for dense containers
+type bitmapContainer struct {
+	words []uint64
+	card  int
+}
+
+func newBitmapContainer() *bitmapContainer {
+	return &bitmapContainer{words: make([]uint64, bitmapWords)}
+}
+
+func (c *bitmapContainer) add(x uint16) container {
+	w := &c.words[x/64]
+	if *w&(1<<(x%64)) == 0 {
+		*w |= 1 << (x % 64)
+		c.card++
+	}
+	return c
+}
+
+func (c *bitmapContainer) remove(x uint16) container {
+	w := &c.words[x/64]
+	if *w&(1<<(x%64)) != 0 {
+		*w &^= 1 << (x % 64)
+		c.card--
+	}
+	if c.card <= arrayMaxSize {
+		return c.toArray()
+	}
+	return c
+}
+
+func (c *bitmapContainer) contains(x uint16) bool {
+	return c.words[x/64]&(1<<(x%64)) != 0
+}
+
+func (c *bitmapContainer) cardinality() int {
+	return c.card
+}
+
+func (c *bitmapContainer) next(x uint16) (uint16, bool) {
+	i := int(x / 64)
+	if w := c.words[i] >> (x % 64); w != 0 {
+		return x + uint16(bits.TrailingZeros64(w)), true
+	}
+	for i++; i < bitmapWords; i++ {
+		if c.words[i] != 0 {
+			return uint16(i*64 + bits.TrailingZeros64(c.words[i])), true
+		}
+	}
+	return 0, false
+}
+
+func (c *bitmapContainer) traversal(fn func(x uint16) bool) bool {
+	for i, w := range c.words {
+		for w != 0 {
+			if !fn(uint16(i*64 + bits.TrailingZeros64(w))) {
+				return false
+			}
+			w &= w - 1
+		}
+	}
+	return true
+}
+
+func (c *bitmapContainer) toArray() *arrayContainer {
+	values := make([]uint16, 0, c.card)
+	c.traversal(func(x uint16) bool {
+		values = append(values, x)
+		return true
+	})
+	return &arrayContainer{values: values}
+}
+
+func (c *bitmapContainer) asBitmap() *bitmapContainer {
+	return c
+}
+
+func (c *bitmapContainer) clone() container {
+	b := newBitmapContainer()
+	copy(b.words, c.words)
+	b.card = c.card
+	return b
+}
+
+func (c *bitmapContainer) numRuns() int {
+	n := 0
+	var carry uint64
+	for _, w := range c.words {
+		// a run starts at each 1 whose previous bit is 0
+		n += bits.OnesCount64(w &^ (w<<1 | carry))
+		carry = w >> 63
+	}
+	return n
+}
+
+// setRange sets the bits in range [lo, hi]
+func (c *bitmapContainer) setRange(lo, hi uint16) {
+	first, last := int(lo/64), int(hi/64)
+	loMask := ^uint64(0) << (lo % 64)
+	hiMask := ^uint64(0) >> (63 - hi%64)
+	if first == last {
+		c.words[first] |= loMask & hiMask
+		return
+	}
+	c.words[first] |= loMask
+	for i := first + 1; i < last; i++ {
+		c.words[i] = ^uint64(0)
+	}
+	c.words[last] |= hiMask
+}
+
+func (c *bitmapContainer) computeCardinality() {
+	c.card = 0
+	for _, w := range c.words {
+		c.card += bits.OnesCount64(w)
+	}
+}
+
+// interval is a run of values in range [start, last]
+type interval struct {
+	start uint16
+	last  uint16
+}
+
+func (iv interval) cardinality() int {
+	return int(iv.last) - int(iv.start) + 1
+}
+
+// runContainer is a sorted list of disjoint and non-adjacent runs, it is used for containers with long runs
+type runContainer struct {
+	runs []interval
+}
+
+func newRunContainer(runs ...interval) *runContainer {
+	return &runContainer{runs: runs}
+}
+
+// search returns the index of the last run starting at or before x, or -1
+func (c *runContainer) search(x uint16) int {
+	return sort.Search(len(c.runs), func(i int) bool { return c.runs[i].start > x }) - 1
+}
+
+func (c *runContainer) add(x uint16) container {
+	i := c.search(x)
+	if i >= 0 && x <= c.runs[i].last {
+		return c
+	}
+	extendPrev := i >= 0 && c.runs[i].last+1 == x
+	extendNext := i+1 < len(c.runs) && c.runs[i+1].start == x+1
+	switch {
+	case extendPrev && extendNext:
+		c.runs[i].last = c.runs[i+1].last
+		c.runs = append(c.runs[:i+1], c.runs[i+2:]...)
+	case extendPrev:
+		c.runs[i].last = x
+	case extendNext:
+		c.runs[i+1].start = x
+	default:
+		c.runs = append(c.runs, interval{})
+		copy(c.runs[i+2:], c.runs[i+1:])
+		c.runs[i+1] = interval{start: x, last: x}
+	}
+	return optimize(c)
+}
+
+func (c *runContainer) remove(x uint16) container {
+	i := c.search(x)
+	if i < 0 || x > c.runs[i].last {
+		return c
+	}
+	iv := c.runs[i]
+	switch {
+	case iv.start == iv.last:
+		c.runs = append(c.runs[:i], c.runs[i+1:]...)
+	case x == iv.start:
+		c.runs[i].start++
+	case x == iv.last:
+		c.runs[i].last--
+	default:
+		c.runs = append(c.runs, interval{})
+		copy(c.runs[i+1:], c.runs[i:])
+		c.runs[i].last = x - 1
+		c.runs[i+1].start = x + 1
+	}
+	return optimize(c)
+}
+
+func (c *runContainer) contains(x uint16) bool {
+	i := c.search(x)
+	return i >= 0 && x <= c.runs[i].last
+}
+
+func (c *runContainer) cardinality() int {
+	n := 0
+	for _, iv := range c.runs {
+		n += iv.cardinality()
+	}
+	return n
+}
+
+func (c *runContainer) next(x uint16) (uint16, bool) {
+	i := c.search(x)
+	if i >= 0 && x <= c.runs[i].last {
+		return x, true
+	}
+	if i+1 < len(c.runs) {
+		return c.runs[i+1].start, true
+	}
+	return 0, false
+}
+
+func (c *runContainer) traversal(fn func(x uint16) bool) bool {
+	for _, iv := range c.runs {
+		for v := int(iv.start); v <= int(iv.last); v++ {
+			if !fn(uint16(v)) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (c *runContainer) toBitmap() *bitmapContainer {
+	b := newBitmapContainer()
+	for _, iv := range c.runs {
+		b.setRange(iv.start, iv.last)
+		b.card += iv.cardinality()
+	}
+	return b
+}
+
+func (c *runContainer) toArray() *arrayContainer {
+	values := make([]uint16, 0, c.cardinality())
+	for _, iv := range c.runs {
+		for v := int(iv.start); v <= int(iv.last); v++ {
+			values = append(values, uint16(v))
+		}
+	}
+	return &arrayContainer{values: values}
+}
+
+func (c *runContainer) asBitmap() *bitmapContainer {
+	return c.toBitmap()
+}
+
+func (c *runContainer) clone() container {
+	runs := make([]interval, len(c.runs))
+	copy(runs, c.runs)
+	return &runContainer{runs: runs}
+}
+
+func (c *runContainer) numRuns() int {
+	return len(c.runs)
+}
+
+// serialized sizes of the containers in bytes
+func arraySize(card int) int {
+	return 2 * card
+}
+
+func runSize(runs int) int {
+	return 2 + 4*runs
+}
+
+// optimize converts the container to the kind with the smallest serialized size
+func optimize(c container) container {
+	card, runs := c.cardinality(), c.numRuns()
+	size := bitmapBytes
+	if card <= arrayMaxSize {
+		size = arraySize(card)
+	}
+	if runSize(runs) < size {
+		switch v := c.(type) {
+		case *runContainer:
+			return v
+		case *arrayContainer:
+			return v.toRun()
+		case *bitmapContainer:
+			return v.toRun()
+		}
+	}
+	return normalize(c)
+}
+
+// normalize converts the container to an array container or a bitmap container by its cardinality
+func normalize(c container) container {
+	switch v := c.(type) {
+	case *arrayContainer:
+		if len(v.values) > arrayMaxSize {
+			return v.toBitmap()
+		}
+		return v
+	case *bitmapContainer:
+		if v.card <= arrayMaxSize {
+			return v.toArray()
+		}
+		return v
+	case *runContainer:
+		if v.cardinality() <= arrayMaxSize {
+			return v.toArray()
+		}
+		return v.toBitmap()
+	}
+	return c
+}
+
+func (c *arrayContainer) toRun() *runContainer {
+	r := &runContainer{runs: make([]interval, 0, c.numRuns())}
+	for i, v := range c.values {
+		if i > 0 && v == c.values[i-1]+1 {
+			r.runs[len(r.runs)-1].last = v
+			continue
+		}
+		r.runs = append(r.runs, interval{start: v, last: v})
+	}
+	return r
+}
+
+func (c *bitmapContainer) toRun() *runContainer {
+	r := &runContainer{runs: make([]interval, 0, c.numRuns())}
+	c.traversal(func(v uint16) bool {
+		if n := len(r.runs); n > 0 && int(r.runs[n-1].last)+1 == int(v) {
+			r.runs[n-1].last = v
+		} else {
+			r.runs = append(r.runs, interval{start: v, last: v})
+		}
+		return true
+	})
+	return r
+}
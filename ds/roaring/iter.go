@@ -0,0 +1,12 @@
+//go:build go1.23
+
+package roaring
+
+import "iter"
+
+// All returns an iterator over the values in the bitmap, in ascending order
+func (b *Bitmap) All() iter.Seq[uint32] {
+	return func(yield func(uint32) bool) {
+		b.Traversal(yield)
+	}
+}
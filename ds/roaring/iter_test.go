@@ -0,0 +1,18 @@
+//go:build go1.23
+
+package roaring
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAll(t *testing.T) {
+	b := Of(7, 1, 1<<20)
+	var values []uint32
+	for v := range b.All() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []uint32{1, 7, 1 << 20}, values)
+}
@@ -0,0 +1,61 @@
+package roaring
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+var _ iterator.ConstIterator[uint32] = (*BitmapIterator)(nil)
+
+// BitmapIterator is an iterator over the values of a Bitmap in ascending order,
+// it is invalidated by any modification of the Bitmap
+type BitmapIterator struct {
+	b     *Bitmap
+	index int    // index of the current container
+	low   uint16 // low 16 bits of the current value
+}
+
+// IsValid returns true if the iterator is valid, otherwise returns false
+func (iter *BitmapIterator) IsValid() bool {
+	return iter.index < len(iter.b.containers)
+}
+
+// Next moves the iterator to the next value, and returns itself
+func (iter *BitmapIterator) Next() iterator.ConstIterator[uint32] {
+	if !iter.IsValid() {
+		return iter
+	}
+	if iter.low < maxLowValue {
+		if low, ok := iter.b.containers[iter.index].next(iter.low + 1); ok {
+			iter.low = low
+			return iter
+		}
+	}
+	iter.index++
+	iter.low = 0
+	if iter.IsValid() {
+		iter.low, _ = iter.b.containers[iter.index].next(0)
+	}
+	return iter
+}
+
+// Value returns the value the iterator point to
+func (iter *BitmapIterator) Value() uint32 {
+	return uint32(iter.b.keys[iter.index])<<16 | uint32(iter.low)
+}
+
+// Clone clones the iterator to a new BitmapIterator
+func (iter *BitmapIterator) Clone() iterator.ConstIterator[uint32] {
+	return &BitmapIterator{b: iter.b, index: iter.index, low: iter.low}
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *BitmapIterator) Equal(other iterator.ConstIterator[uint32]) bool {
+	otherIter, ok := other.(*BitmapIterator)
+	if !ok {
+		return false
+	}
+	if otherIter.b != iter.b || otherIter.index != iter.index {
+		return false
+	}
+	return !iter.IsValid() || otherIter.low == iter.low
+}
@@ -0,0 +1,166 @@
+package roaring
+
+// and returns a new container holding the values in both a and b
+func and(a, b container) container {
+	ra, aRun := a.(*runContainer)
+	rb, bRun := b.(*runContainer)
+	if aRun && bRun {
+		return optimize(andRuns(ra, rb))
+	}
+	if aa, ok := a.(*arrayContainer); ok {
+		return filter(aa, b, true)
+	}
+	if ab, ok := b.(*arrayContainer); ok {
+		return filter(ab, a, true)
+	}
+	return combine(a, b, func(x, y uint64) uint64 { return x & y })
+}
+
+// or returns a new container holding the values in a or b
+func or(a, b container) container {
+	ra, aRun := a.(*runContainer)
+	rb, bRun := b.(*runContainer)
+	if aRun && bRun {
+		return optimize(orRuns(ra, rb))
+	}
+	aa, aArray := a.(*arrayContainer)
+	ab, bArray := b.(*arrayContainer)
+	if aArray && bArray {
+		return normalize(mergeArrays(aa, ab, false))
+	}
+	return combine(a, b, func(x, y uint64) uint64 { return x | y })
+}
+
+// xor returns a new container holding the values in exactly one of a and b
+func xor(a, b container) container {
+	aa, aArray := a.(*arrayContainer)
+	ab, bArray := b.(*arrayContainer)
+	if aArray && bArray {
+		return normalize(mergeArrays(aa, ab, true))
+	}
+	return combine(a, b, func(x, y uint64) uint64 { return x ^ y })
+}
+
+// andNot returns a new container holding the values in a but not in b
+func andNot(a, b container) container {
+	if aa, ok := a.(*arrayContainer); ok {
+		return filter(aa, b, false)
+	}
+	if ab, ok := b.(*arrayContainer); ok {
+		r := bitmapCopy(a)
+		for _, v := range ab.values {
+			if r.contains(v) {
+				r.words[v/64] &^= 1 << (v % 64)
+				r.card--
+			}
+		}
+		return normalize(r)
+	}
+	return combine(a, b, func(x, y uint64) uint64 { return x &^ y })
+}
+
+// bitmapCopy returns a modifiable bitmap container holding the values of c
+func bitmapCopy(c container) *bitmapContainer {
+	if b, ok := c.(*bitmapContainer); ok {
+		return b.clone().(*bitmapContainer)
+	}
+	return c.asBitmap()
+}
+
+// filter returns the values of a which are (or are not, if keep is false) in b
+func filter(a *arrayContainer, b container, keep bool) container {
+	values := make([]uint16, 0, len(a.values))
+	for _, v := range a.values {
+		if b.contains(v) == keep {
+			values = append(values, v)
+		}
+	}
+	return &arrayContainer{values: values}
+}
+
+// combine combines the words of a and b with op
+func combine(a, b container, op func(x, y uint64) uint64) container {
+	wa, wb := a.asBitmap().words, b.asBitmap().words
+	r := newBitmapContainer()
+	for i := range r.words {
+		r.words[i] = op(wa[i], wb[i])
+	}
+	r.computeCardinality()
+	return normalize(r)
+}
+
+// mergeArrays returns the union of a and b, or the symmetric difference if exclusive is true
+func mergeArrays(a, b *arrayContainer, exclusive bool) *arrayContainer {
+	values := make([]uint16, 0, len(a.values)+len(b.values))
+	i, j := 0, 0
+	for i < len(a.values) && j < len(b.values) {
+		x, y := a.values[i], b.values[j]
+		switch {
+		case x < y:
+			values = append(values, x)
+			i++
+		case x > y:
+			values = append(values, y)
+			j++
+		default:
+			if !exclusive {
+				values = append(values, x)
+			}
+			i++
+			j++
+		}
+	}
+	values = append(values, a.values[i:]...)
+	values = append(values, b.values[j:]...)
+	return &arrayContainer{values: values}
+}
+
+// andRuns returns the intersection of the runs of a and b
+func andRuns(a, b *runContainer) *runContainer {
+	r := &runContainer{}
+	i, j := 0, 0
+	for i < len(a.runs) && j < len(b.runs) {
+		x, y := a.runs[i], b.runs[j]
+		start, last := x.start, x.last
+		if y.start > start {
+			start = y.start
+		}
+		if y.last < last {
+			last = y.last
+		}
+		if start <= last {
+			r.runs = append(r.runs, interval{start: start, last: last})
+		}
+		if x.last < y.last {
+			i++
+		} else {
+			j++
+		}
+	}
+	return r
+}
+
+// orRuns returns the union of the runs of a and b
+func orRuns(a, b *runContainer) *runContainer {
+	r := &runContainer{runs: make([]interval, 0, len(a.runs)+len(b.runs))}
+	push := func(iv interval) {
+		if n := len(r.runs); n > 0 && int(iv.start) <= int(r.runs[n-1].last)+1 {
+			if iv.last > r.runs[n-1].last {
+				r.runs[n-1].last = iv.last
+			}
+			return
+		}
+		r.runs = append(r.runs, iv)
+	}
+	i, j := 0, 0
+	for i < len(a.runs) || j < len(b.runs) {
+		if j >= len(b.runs) || (i < len(a.runs) && a.runs[i].start <= b.runs[j].start) {
+			push(a.runs[i])
+			i++
+		} else {
+			push(b.runs[j])
+			j++
+		}
+	}
+	return r
+}
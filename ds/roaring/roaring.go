@@ -0,0 +1,265 @@
+package roaring
+
+import (
+	"sort"
+
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// Bitmap is a compressed bitmap of uint32 values. The values are partitioned by their high 16 bits into containers,
+// and each container holds the low 16 bits in a sorted array, a bitset or a list of runs, whichever is smaller
+type Bitmap struct {
+	keys       []uint16
+	containers []container
+}
+
+// New creates an empty Bitmap
+func New() *Bitmap {
+	return &Bitmap{}
+}
+
+// Of creates a Bitmap holding the passed values
+func Of(values ...uint32) *Bitmap {
+	b := New()
+	for _, v := range values {
+		b.Add(v)
+	}
+	return b
+}
+
+func highLow(x uint32) (uint16, uint16) {
+	return uint16(x >> 16), uint16(x)
+}
+
+// search returns the index of the container with the key, and true if it exists
+func (b *Bitmap) search(key uint16) (int, bool) {
+	i := sort.Search(len(b.keys), func(i int) bool { return b.keys[i] >= key })
+	return i, i < len(b.keys) && b.keys[i] == key
+}
+
+func (b *Bitmap) insertAt(i int, key uint16, c container) {
+	b.keys = append(b.keys, 0)
+	copy(b.keys[i+1:], b.keys[i:])
+	b.keys[i] = key
+	b.containers = append(b.containers, nil)
+	copy(b.containers[i+1:], b.containers[i:])
+	b.containers[i] = c
+}
+
+func (b *Bitmap) removeAt(i int) {
+	b.keys = append(b.keys[:i], b.keys[i+1:]...)
+	copy(b.containers[i:], b.containers[i+1:])
+	b.containers[len(b.containers)-1] = nil
+	b.containers = b.containers[:len(b.containers)-1]
+}
+
+// Add adds x into the bitmap
+func (b *Bitmap) Add(x uint32) {
+	high, low := highLow(x)
+	i, ok := b.search(high)
+	if ok {
+		b.containers[i] = b.containers[i].add(low)
+		return
+	}
+	b.insertAt(i, high, newArrayContainer(low))
+}
+
+// AddRange adds the values in range [lo, hi) into the bitmap
+func (b *Bitmap) AddRange(lo, hi uint64) {
+	if hi > 1<<32 {
+		hi = 1 << 32
+	}
+	for lo < hi {
+		high := uint16(lo >> 16)
+		last := uint64(high)<<16 | maxLowValue
+		if last > hi-1 {
+			last = hi - 1
+		}
+		run := newRunContainer(interval{start: uint16(lo), last: uint16(last)})
+		if i, ok := b.search(high); ok {
+			b.containers[i] = optimize(or(b.containers[i], run))
+		} else {
+			b.insertAt(i, high, optimize(run))
+		}
+		lo = last + 1
+	}
+}
+
+// Remove removes x from the bitmap
+func (b *Bitmap) Remove(x uint32) {
+	high, low := highLow(x)
+	i, ok := b.search(high)
+	if !ok {
+		return
+	}
+	b.containers[i] = b.containers[i].remove(low)
+	if b.containers[i].cardinality() == 0 {
+		b.removeAt(i)
+	}
+}
+
+// Contains returns true if x is in the bitmap
+func (b *Bitmap) Contains(x uint32) bool {
+	high, low := highLow(x)
+	i, ok := b.search(high)
+	return ok && b.containers[i].contains(low)
+}
+
+// Cardinality returns the amount of values in the bitmap
+func (b *Bitmap) Cardinality() uint64 {
+	n := uint64(0)
+	for _, c := range b.containers {
+		n += uint64(c.cardinality())
+	}
+	return n
+}
+
+// IsEmpty returns true if the bitmap is empty
+func (b *Bitmap) IsEmpty() bool {
+	return len(b.containers) == 0
+}
+
+// Clear removes all values in the bitmap
+func (b *Bitmap) Clear() {
+	b.keys = nil
+	b.containers = nil
+}
+
+// Clone returns a copy of the bitmap
+func (b *Bitmap) Clone() *Bitmap {
+	r := &Bitmap{
+		keys:       make([]uint16, len(b.keys)),
+		containers: make([]container, len(b.containers)),
+	}
+	copy(r.keys, b.keys)
+	for i, c := range b.containers {
+		r.containers[i] = c.clone()
+	}
+	return r
+}
+
+// RunOptimize converts the containers to run containers if they are smaller in that way, and vice versa
+func (b *Bitmap) RunOptimize() {
+	for i, c := range b.containers {
+		b.containers[i] = optimize(c)
+	}
+}
+
+// Equal returns true if the bitmap holds the same values as other
+func (b *Bitmap) Equal(other *Bitmap) bool {
+	if len(b.keys) != len(other.keys) {
+		return false
+	}
+	for i, key := range b.keys {
+		c, o := b.containers[i], other.containers[i]
+		if key != other.keys[i] || c.cardinality() != o.cardinality() || !c.traversal(o.contains) {
+			return false
+		}
+	}
+	return true
+}
+
+// And sets the bitmap to the intersection of itself and other, and returns the bitmap itself
+func (b *Bitmap) And(other *Bitmap) *Bitmap {
+	*b = *And(b, other)
+	return b
+}
+
+// Or sets the bitmap to the union of itself and other, and returns the bitmap itself
+func (b *Bitmap) Or(other *Bitmap) *Bitmap {
+	*b = *Or(b, other)
+	return b
+}
+
+// Xor sets the bitmap to the symmetric difference of itself and other, and returns the bitmap itself
+func (b *Bitmap) Xor(other *Bitmap) *Bitmap {
+	*b = *Xor(b, other)
+	return b
+}
+
+// AndNot removes the values in other from the bitmap, and returns the bitmap itself
+func (b *Bitmap) AndNot(other *Bitmap) *Bitmap {
+	*b = *AndNot(b, other)
+	return b
+}
+
+// And returns a new bitmap holding the values in both a and b
+func And(a, b *Bitmap) *Bitmap {
+	return merge(a, b, and, false, false)
+}
+
+// Or returns a new bitmap holding the values in a or b
+func Or(a, b *Bitmap) *Bitmap {
+	return merge(a, b, or, true, true)
+}
+
+// Xor returns a new bitmap holding the values in exactly one of a and b
+func Xor(a, b *Bitmap) *Bitmap {
+	return merge(a, b, xor, true, true)
+}
+
+// AndNot returns a new bitmap holding the values in a but not in b
+func AndNot(a, b *Bitmap) *Bitmap {
+	return merge(a, b, andNot, true, false)
+}
+
+// merge combines the containers of a and b with the same key by op,
+// and copies the containers only in a or only in b if keepA or keepB is true
+func merge(a, b *Bitmap, op func(x, y container) container, keepA, keepB bool) *Bitmap {
+	r := New()
+	push := func(key uint16, c container) {
+		if c.cardinality() > 0 {
+			r.keys = append(r.keys, key)
+			r.containers = append(r.containers, c)
+		}
+	}
+	i, j := 0, 0
+	for i < len(a.keys) || j < len(b.keys) {
+		switch {
+		case j >= len(b.keys) || (i < len(a.keys) && a.keys[i] < b.keys[j]):
+			if keepA {
+				push(a.keys[i], a.containers[i].clone())
+			}
+			i++
+		case i >= len(a.keys) || a.keys[i] > b.keys[j]:
+			if keepB {
+				push(b.keys[j], b.containers[j].clone())
+			}
+			j++
+		default:
+			push(a.keys[i], op(a.containers[i], b.containers[j]))
+			i++
+			j++
+		}
+	}
+	return r
+}
+
+// Traversal traversals the values in ascending order, it will not stop until to the end or the visitor returns false
+func (b *Bitmap) Traversal(visitor visitor.Visitor[uint32]) {
+	for i, c := range b.containers {
+		high := uint32(b.keys[i]) << 16
+		if !c.traversal(func(low uint16) bool { return visitor(high | uint32(low)) }) {
+			return
+		}
+	}
+}
+
+// ToArray returns the values in ascending order
+func (b *Bitmap) ToArray() []uint32 {
+	values := make([]uint32, 0, b.Cardinality())
+	b.Traversal(func(v uint32) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+
+// Begin returns an iterator pointing to the smallest value
+func (b *Bitmap) Begin() *BitmapIterator {
+	iter := &BitmapIterator{b: b}
+	if !b.IsEmpty() {
+		iter.low, _ = b.containers[0].next(0)
+	}
+	return iter
+}
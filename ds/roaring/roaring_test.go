@@ -0,0 +1,364 @@
+package roaring
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+// randomBitmap returns a bitmap and its reference set, with sparse, dense and run containers
+func randomBitmap(r *rand.Rand) (*Bitmap, map[uint32]bool) {
+	b := New()
+	ref := make(map[uint32]bool)
+	for k := 0; k < 6; k++ {
+		high := uint32(r.Intn(8)) << 16
+		switch r.Intn(3) {
+		case 0:
+			for i := 0; i < 100; i++ {
+				v := high | uint32(r.Intn(1<<16))
+				b.Add(v)
+				ref[v] = true
+			}
+		case 1:
+			for i := 0; i < 10000; i++ {
+				v := high | uint32(r.Intn(1<<16))
+				b.Add(v)
+				ref[v] = true
+			}
+		default:
+			lo := uint64(high) + uint64(r.Intn(1<<16))
+			hi := lo + uint64(r.Intn(1<<16))
+			b.AddRange(lo, hi)
+			for v := lo; v < hi; v++ {
+				ref[uint32(v)] = true
+			}
+		}
+	}
+	return b, ref
+}
+
+func sorted(ref map[uint32]bool) []uint32 {
+	values := make([]uint32, 0, len(ref))
+	for v, ok := range ref {
+		if ok {
+			values = append(values, v)
+		}
+	}
+	sort.Slice(values, func(i, j int) bool { return values[i] < values[j] })
+	return values
+}
+
+func checkBitmap(t *testing.T, b *Bitmap, ref map[uint32]bool) {
+	values := sorted(ref)
+	assert.Equal(t, uint64(len(values)), b.Cardinality())
+	assert.Equal(t, values, b.ToArray())
+	for i, c := range b.containers {
+		assert.NotZero(t, c.cardinality())
+		if i > 0 {
+			assert.Less(t, b.keys[i-1], b.keys[i])
+		}
+		switch v := c.(type) {
+		case *arrayContainer:
+			assert.LessOrEqual(t, len(v.values), arrayMaxSize)
+		case *bitmapContainer:
+			assert.Greater(t, v.card, arrayMaxSize)
+		}
+	}
+}
+
+func TestBasic(t *testing.T) {
+	b := New()
+	assert.True(t, b.IsEmpty())
+	b.Add(1)
+	b.Add(100000)
+	b.Add(1)
+	b.Add(1 << 31)
+	assert.Equal(t, uint64(3), b.Cardinality())
+	assert.True(t, b.Contains(100000))
+	assert.False(t, b.Contains(2))
+
+	b.Remove(100000)
+	b.Remove(5)
+	assert.False(t, b.Contains(100000))
+	assert.Equal(t, []uint32{1, 1 << 31}, b.ToArray())
+	assert.Equal(t, 2, len(b.containers))
+
+	c := b.Clone()
+	c.Add(7)
+	assert.False(t, b.Contains(7))
+	assert.False(t, b.Equal(c))
+	c.Remove(7)
+	assert.True(t, b.Equal(c))
+
+	b.Clear()
+	assert.True(t, b.IsEmpty())
+	assert.Equal(t, uint64(0), b.Cardinality())
+}
+
+func TestContainerConversion(t *testing.T) {
+	b := New()
+	for i := uint32(0); i < arrayMaxSize; i++ {
+		b.Add(i * 2)
+	}
+	assert.IsType(t, &arrayContainer{}, b.containers[0])
+	b.Add(1)
+	assert.IsType(t, &bitmapContainer{}, b.containers[0])
+	b.Remove(1)
+	assert.IsType(t, &arrayContainer{}, b.containers[0])
+
+	b.Clear()
+	b.AddRange(10, 60000)
+	assert.IsType(t, &runContainer{}, b.containers[0])
+	assert.Equal(t, uint64(59990), b.Cardinality())
+	b.Remove(100)
+	b.Add(5)
+	b.Add(9)
+	assert.IsType(t, &runContainer{}, b.containers[0])
+	assert.Equal(t, []interval{{5, 5}, {9, 99}, {101, 59999}}, b.containers[0].(*runContainer).runs)
+	b.Add(100)
+	assert.Equal(t, []interval{{5, 5}, {9, 59999}}, b.containers[0].(*runContainer).runs)
+
+	// a run container with too many runs becomes a bitmap
+	b.Clear()
+	b.AddRange(0, 1<<16)
+	for i := uint32(0); i < 1<<16; i += 2 {
+		b.Remove(i)
+	}
+	assert.IsType(t, &bitmapContainer{}, b.containers[0])
+	assert.Equal(t, uint64(1<<15), b.Cardinality())
+
+	b.Clear()
+	for i := uint32(0); i < 10000; i++ {
+		b.Add(i)
+	}
+	assert.IsType(t, &bitmapContainer{}, b.containers[0])
+	b.RunOptimize()
+	assert.IsType(t, &runContainer{}, b.containers[0])
+}
+
+func TestAddRange(t *testing.T) {
+	b := New()
+	b.AddRange(65530, 65540+1<<16)
+	assert.Equal(t, uint64(10+1<<16), b.Cardinality())
+	assert.Equal(t, 3, len(b.containers))
+	assert.False(t, b.Contains(65529))
+	assert.True(t, b.Contains(65530))
+	assert.True(t, b.Contains(65539+1<<16))
+	assert.False(t, b.Contains(65540+1<<16))
+
+	b.Clear()
+	b.AddRange(1<<32-2, 1<<40)
+	assert.Equal(t, []uint32{1<<32 - 2, 1<<32 - 1}, b.ToArray())
+	b.AddRange(5, 5)
+	assert.Equal(t, uint64(2), b.Cardinality())
+}
+
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for round := 0; round < 8; round++ {
+		b, ref := randomBitmap(r)
+		checkBitmap(t, b, ref)
+		if round%2 == 0 {
+			b.RunOptimize()
+			checkBitmap(t, b, ref)
+		}
+		for i := 0; i < 20000; i++ {
+			v := uint32(r.Intn(8))<<16 | uint32(r.Intn(1<<16))
+			if r.Intn(2) == 0 {
+				b.Remove(v)
+				delete(ref, v)
+			} else {
+				b.Add(v)
+				ref[v] = true
+			}
+			assert.Equal(t, ref[v], b.Contains(v))
+		}
+		checkBitmap(t, b, ref)
+	}
+}
+
+func TestBooleanOps(t *testing.T) {
+	r := rand.New(rand.NewSource(2))
+	for round := 0; round < 12; round++ {
+		a, ra := randomBitmap(r)
+		b, rb := randomBitmap(r)
+		if round%3 == 0 {
+			a.RunOptimize()
+		}
+		if round%2 == 0 {
+			b.RunOptimize()
+		}
+		and, or, xor, andNot := map[uint32]bool{}, map[uint32]bool{}, map[uint32]bool{}, map[uint32]bool{}
+		for v := range ra {
+			or[v] = true
+			if rb[v] {
+				and[v] = true
+			} else {
+				xor[v] = true
+				andNot[v] = true
+			}
+		}
+		for v := range rb {
+			or[v] = true
+			if !ra[v] {
+				xor[v] = true
+			}
+		}
+		checkBitmap(t, And(a, b), and)
+		checkBitmap(t, Or(a, b), or)
+		checkBitmap(t, Xor(a, b), xor)
+		checkBitmap(t, AndNot(a, b), andNot)
+		checkBitmap(t, a, ra)
+		checkBitmap(t, b, rb)
+
+		c := a.Clone()
+		c.Or(b).AndNot(b)
+		checkBitmap(t, c, andNot)
+		c.Xor(a)
+		checkBitmap(t, c, and)
+		c.And(b)
+		checkBitmap(t, c, and)
+	}
+}
+
+func TestIterator(t *testing.T) {
+	b := Of(3, 65535, 65536, 1<<20, 1<<32-1)
+	var values []uint32
+	for iter := b.Begin(); iter.IsValid(); iter.Next() {
+		values = append(values, iter.Value())
+	}
+	assert.Equal(t, b.ToArray(), values)
+
+	values = values[:0]
+	b.Traversal(func(v uint32) bool {
+		values = append(values, v)
+		return len(values) < 2
+	})
+	assert.Equal(t, []uint32{3, 65535}, values)
+
+	iter := b.Begin()
+	clone := iter.Clone()
+	assert.True(t, iter.Equal(clone))
+	clone.Next()
+	assert.False(t, iter.Equal(clone))
+	assert.Equal(t, uint32(65535), clone.Value())
+	assert.False(t, New().Begin().IsValid())
+
+	b = New()
+	b.AddRange(100, 200)
+	b.RunOptimize()
+	n := uint32(100)
+	for iter := b.Begin(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, n, iter.Value())
+		n++
+	}
+	assert.Equal(t, uint32(200), n)
+}
+
+func TestSerializationFormat(t *testing.T) {
+	// without run containers
+	b := Of(1, 2, 3, 100000)
+	data, err := b.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		0x3a, 0x30, 0, 0, 2, 0, 0, 0, // cookie, amount of containers
+		0, 0, 2, 0, 1, 0, 0, 0, // keys and cardinalities - 1
+		24, 0, 0, 0, 30, 0, 0, 0, // offsets
+		1, 0, 2, 0, 3, 0, // array container
+		0xa0, 0x86, // array container
+	}, data)
+	assert.Equal(t, len(data), b.SerializedSize())
+
+	// with a run container
+	b = New()
+	b.AddRange(0, 100)
+	data, err = b.MarshalBinary()
+	assert.Nil(t, err)
+	assert.Equal(t, []byte{
+		0x3b, 0x30, 0, 0, // cookie with amount of containers - 1
+		1,           // run flags
+		0, 0, 99, 0, // key and cardinality - 1
+		1, 0, 0, 0, 99, 0, // run container
+	}, data)
+
+	other := New()
+	assert.Nil(t, other.UnmarshalBinary(data))
+	assert.True(t, b.Equal(other))
+	assert.IsType(t, &runContainer{}, other.containers[0])
+}
+
+func TestSerializationRoundTrip(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	for round := 0; round < 10; round++ {
+		b, ref := randomBitmap(r)
+		if round%2 == 0 {
+			b.RunOptimize()
+		}
+		var buf bytes.Buffer
+		n, err := b.WriteTo(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(b.SerializedSize()), n)
+		buf.WriteString("tail")
+
+		other := Of(12345)
+		m, err := other.ReadFrom(&buf)
+		assert.Nil(t, err)
+		assert.Equal(t, n, m)
+		assert.Equal(t, "tail", buf.String())
+		checkBitmap(t, other, ref)
+		assert.True(t, b.Equal(other))
+	}
+
+	empty := New()
+	data, _ := empty.MarshalBinary()
+	other := Of(1)
+	assert.Nil(t, other.UnmarshalBinary(data))
+	assert.True(t, other.IsEmpty())
+}
+
+func TestSerializationCorrupt(t *testing.T) {
+	b := Of(1, 2, 3, 100000)
+	data, _ := b.MarshalBinary()
+	for i := 0; i < len(data); i++ {
+		assert.Equal(t, codec.ErrorCorruptData, New().UnmarshalBinary(data[:i]))
+	}
+	assert.Equal(t, codec.ErrorCorruptData, New().UnmarshalBinary(append(data, 0)))
+
+	bad := append([]byte{}, data...)
+	bad[0] = 0
+	assert.Equal(t, codec.ErrorCorruptData, New().UnmarshalBinary(bad))
+
+	// unsorted values in an array container
+	bad = append([]byte{}, data...)
+	bad[24], bad[26] = 2, 1
+	assert.Equal(t, codec.ErrorCorruptData, New().UnmarshalBinary(bad))
+
+	// the cardinality of a run container doesn't match
+	b = New()
+	b.AddRange(0, 100)
+	data, _ = b.MarshalBinary()
+	data[7] = 98
+	assert.Equal(t, codec.ErrorCorruptData, New().UnmarshalBinary(data))
+}
+
+func BenchmarkAdd(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	bm := New()
+	for i := 0; i < b.N; i++ {
+		bm.Add(r.Uint32())
+	}
+}
+
+func BenchmarkAnd(b *testing.B) {
+	r := rand.New(rand.NewSource(0))
+	x, _ := randomBitmap(r)
+	y, _ := randomBitmap(r)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		And(x, y)
+	}
+}
@@ -0,0 +1,291 @@
+package roaring
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+
+	"github.com/liyue201/gostl/utils/codec"
+)
+
+// Constants of the Roaring portable serialization format, see https://github.com/RoaringBitmap/RoaringFormatSpec
+const (
+	serialCookieNoRun = 12346
+	serialCookie      = 12347
+	noOffsetThreshold = 4
+)
+
+var le = binary.LittleEndian
+
+func (b *Bitmap) hasRun() bool {
+	for _, c := range b.containers {
+		if _, ok := c.(*runContainer); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func headerSize(n int, hasRun bool) int {
+	if !hasRun {
+		return 8 + 8*n
+	}
+	size := 4 + (n+7)/8 + 4*n
+	if n >= noOffsetThreshold {
+		size += 4 * n
+	}
+	return size
+}
+
+func dataSize(c container) int {
+	switch v := c.(type) {
+	case *runContainer:
+		return runSize(len(v.runs))
+	case *bitmapContainer:
+		return bitmapBytes
+	default:
+		return arraySize(c.cardinality())
+	}
+}
+
+// SerializedSize returns the size in bytes of the data returned by MarshalBinary
+func (b *Bitmap) SerializedSize() int {
+	size := headerSize(len(b.containers), b.hasRun())
+	for _, c := range b.containers {
+		size += dataSize(c)
+	}
+	return size
+}
+
+// MarshalBinary encodes the bitmap in the Roaring portable serialization format shared by the Roaring
+// implementations of other languages, it implements encoding.BinaryMarshaler
+func (b *Bitmap) MarshalBinary() ([]byte, error) {
+	n := len(b.containers)
+	hasRun := b.hasRun()
+	buf := make([]byte, b.SerializedSize())
+	pos := 0
+	if hasRun {
+		le.PutUint32(buf, serialCookie|uint32(n-1)<<16)
+		pos = 4
+		for i, c := range b.containers {
+			if _, ok := c.(*runContainer); ok {
+				buf[pos+i/8] |= 1 << (i % 8)
+			}
+		}
+		pos += (n + 7) / 8
+	} else {
+		le.PutUint32(buf, serialCookieNoRun)
+		le.PutUint32(buf[4:], uint32(n))
+		pos = 8
+	}
+	for i, c := range b.containers {
+		le.PutUint16(buf[pos:], b.keys[i])
+		le.PutUint16(buf[pos+2:], uint16(c.cardinality()-1))
+		pos += 4
+	}
+	offset := headerSize(n, hasRun)
+	if !hasRun || n >= noOffsetThreshold {
+		for _, c := range b.containers {
+			le.PutUint32(buf[pos:], uint32(offset))
+			pos += 4
+			offset += dataSize(c)
+		}
+	}
+	for _, c := range b.containers {
+		switch v := c.(type) {
+		case *runContainer:
+			le.PutUint16(buf[pos:], uint16(len(v.runs)))
+			pos += 2
+			for _, iv := range v.runs {
+				le.PutUint16(buf[pos:], iv.start)
+				le.PutUint16(buf[pos+2:], iv.last-iv.start)
+				pos += 4
+			}
+		case *bitmapContainer:
+			for _, w := range v.words {
+				le.PutUint64(buf[pos:], w)
+				pos += 8
+			}
+		case *arrayContainer:
+			for _, x := range v.values {
+				le.PutUint16(buf[pos:], x)
+				pos += 2
+			}
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the bitmap with data in the Roaring portable serialization format,
+// it implements encoding.BinaryUnmarshaler
+func (b *Bitmap) UnmarshalBinary(data []byte) error {
+	r := bytes.NewReader(data)
+	if _, err := b.ReadFrom(r); err != nil {
+		return err
+	}
+	if r.Len() != 0 {
+		return codec.ErrorCorruptData
+	}
+	return nil
+}
+
+// WriteTo writes the bitmap to w in the Roaring portable serialization format, it implements io.WriterTo
+func (b *Bitmap) WriteTo(w io.Writer) (int64, error) {
+	data, err := b.MarshalBinary()
+	if err != nil {
+		return 0, err
+	}
+	n, err := w.Write(data)
+	return int64(n), err
+}
+
+// ReadFrom replaces the content of the bitmap with a bitmap read from r in the Roaring portable serialization format,
+// it reads exactly the bytes of the bitmap and implements io.ReaderFrom
+func (b *Bitmap) ReadFrom(r io.Reader) (int64, error) {
+	d := &decoder{r: r}
+	keys, containers := d.decode()
+	if d.err != nil {
+		if errors.Is(d.err, io.EOF) || errors.Is(d.err, io.ErrUnexpectedEOF) {
+			d.err = codec.ErrorCorruptData
+		}
+		return d.n, d.err
+	}
+	b.keys, b.containers = keys, containers
+	return d.n, nil
+}
+
+// decoder reads a serialized bitmap, it stops reading after the first error
+type decoder struct {
+	r   io.Reader
+	n   int64
+	err error
+}
+
+func (d *decoder) read(size int) []byte {
+	if d.err != nil {
+		return nil
+	}
+	buf := make([]byte, size)
+	n, err := io.ReadFull(d.r, buf)
+	d.n += int64(n)
+	d.err = err
+	return buf
+}
+
+func (d *decoder) fail() {
+	if d.err == nil {
+		d.err = codec.ErrorCorruptData
+	}
+}
+
+func (d *decoder) decode() ([]uint16, []container) {
+	buf := d.read(4)
+	if d.err != nil {
+		return nil, nil
+	}
+	var n int
+	var runFlags []byte
+	cookie := le.Uint32(buf)
+	switch {
+	case cookie&0xffff == serialCookie:
+		n = int(cookie>>16) + 1
+		runFlags = d.read((n + 7) / 8)
+	case cookie == serialCookieNoRun:
+		buf = d.read(4)
+		if d.err != nil {
+			return nil, nil
+		}
+		n = int(le.Uint32(buf))
+		if n > containerSize {
+			d.fail()
+		}
+	default:
+		d.fail()
+	}
+
+	header := d.read(4 * n)
+	if runFlags == nil || n >= noOffsetThreshold {
+		d.read(4 * n)
+	}
+	if d.err != nil {
+		return nil, nil
+	}
+	keys := make([]uint16, n)
+	containers := make([]container, n)
+	for i := 0; i < n && d.err == nil; i++ {
+		keys[i] = le.Uint16(header[4*i:])
+		if i > 0 && keys[i] <= keys[i-1] {
+			d.fail()
+		}
+		card := int(le.Uint16(header[4*i+2:])) + 1
+		isRun := runFlags != nil && runFlags[i/8]&(1<<(i%8)) != 0
+		switch {
+		case isRun:
+			containers[i] = d.decodeRun(card)
+		case card > arrayMaxSize:
+			containers[i] = d.decodeBitmap(card)
+		default:
+			containers[i] = d.decodeArray(card)
+		}
+	}
+	return keys, containers
+}
+
+func (d *decoder) decodeRun(card int) container {
+	buf := d.read(2)
+	if d.err != nil {
+		return nil
+	}
+	buf = d.read(4 * int(le.Uint16(buf)))
+	if d.err != nil {
+		return nil
+	}
+	c := &runContainer{runs: make([]interval, len(buf)/4)}
+	total := 0
+	for i := range c.runs {
+		start, length := le.Uint16(buf[4*i:]), le.Uint16(buf[4*i+2:])
+		if int(start)+int(length) > maxLowValue || (i > 0 && int(start) <= int(c.runs[i-1].last)+1) {
+			d.fail()
+			return nil
+		}
+		c.runs[i] = interval{start: start, last: start + length}
+		total += int(length) + 1
+	}
+	if total != card {
+		d.fail()
+	}
+	return c
+}
+
+func (d *decoder) decodeBitmap(card int) container {
+	buf := d.read(bitmapBytes)
+	if d.err != nil {
+		return nil
+	}
+	c := newBitmapContainer()
+	for i := range c.words {
+		c.words[i] = le.Uint64(buf[8*i:])
+	}
+	c.computeCardinality()
+	if c.card != card {
+		d.fail()
+	}
+	return c
+}
+
+func (d *decoder) decodeArray(card int) container {
+	buf := d.read(2 * card)
+	if d.err != nil {
+		return nil
+	}
+	c := &arrayContainer{values: make([]uint16, card)}
+	for i := range c.values {
+		c.values[i] = le.Uint16(buf[2*i:])
+		if i > 0 && c.values[i] <= c.values[i-1] {
+			d.fail()
+			return nil
+		}
+	}
+	return c
+}
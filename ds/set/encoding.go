@@ -0,0 +1,110 @@
+package set
+
+import (
+	"encoding/json"
+
+	"github.com/liyue201/gostl/utils/codec"
+)
+
+// MarshalBinary encodes the set with its codec, it implements encoding.BinaryMarshaler
+func (s *Set[T]) MarshalBinary() ([]byte, error) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	if err := codec.Check(s.codec); err != nil {
+		return nil, err
+	}
+	var err error
+	buf := codec.AppendUvarint(nil, uint64(s.tree.Size()))
+	for node := s.tree.First(); node != nil; node = node.Next() {
+		if buf, err = s.codec.Encode(buf, node.Key()); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the set with the data returned by MarshalBinary,
+// it implements encoding.BinaryUnmarshaler. Note that the set must be created by New with a comparator
+func (s *Set[T]) UnmarshalBinary(data []byte) error {
+	if err := codec.Check(s.codec); err != nil {
+		return err
+	}
+	n, data, err := codec.ReadLen(data)
+	if err != nil {
+		return err
+	}
+	elements := make([]T, n)
+	for i := 0; i < n; i++ {
+		if elements[i], data, err = s.codec.Decode(data); err != nil {
+			return err
+		}
+	}
+	if len(data) != 0 {
+		return codec.ErrorCorruptData
+	}
+
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.build(elements)
+	return nil
+}
+
+// MarshalJSON encodes the set into a JSON array, it implements json.Marshaler
+func (s *Set[T]) MarshalJSON() ([]byte, error) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	elements := make([]T, 0, s.tree.Size())
+	for node := s.tree.First(); node != nil; node = node.Next() {
+		elements = append(elements, node.Key())
+	}
+	return json.Marshal(elements)
+}
+
+// UnmarshalJSON replaces the content of the set with the data returned by MarshalJSON, it implements json.Unmarshaler.
+// Note that the set must be created by New with a comparator
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var elements []T
+	if err := json.Unmarshal(data, &elements); err != nil {
+		return err
+	}
+
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	s.build(elements)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, it is the same as MarshalBinary
+func (s *Set[T]) GobEncode() ([]byte, error) {
+	return s.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, it is the same as UnmarshalBinary
+func (s *Set[T]) GobDecode(data []byte) error {
+	return s.UnmarshalBinary(data)
+}
+
+// build replaces the content of the set with the elements, it takes O(n) time if the elements are
+// strictly increasing wrt the set's comparator, otherwise the elements are inserted one by one
+func (s *Set[T]) build(elements []T) {
+	for i := 1; i < len(elements); i++ {
+		if s.keyCmp(elements[i-1], elements[i]) >= 0 {
+			s.tree.Clear()
+			for _, element := range elements {
+				if s.tree.FindNode(element) == nil {
+					s.tree.Insert(element, Empty)
+				}
+			}
+			return
+		}
+	}
+	values := make([]bool, len(elements))
+	for i := range values {
+		values[i] = Empty
+	}
+	s.tree.BuildFromSorted(elements, values)
+}
@@ -0,0 +1,63 @@
+package set
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetBinary(t *testing.T) {
+	s := New(comparator.IntComparator, WithCodec[int](codec.Integer[int]{}))
+	for i := 0; i < 100; i++ {
+		s.Insert(i * 3)
+	}
+	data, err := s.MarshalBinary()
+	assert.Nil(t, err)
+
+	s2 := New(comparator.IntComparator, WithCodec[int](codec.Integer[int]{}))
+	assert.Nil(t, s2.UnmarshalBinary(data))
+	assert.Equal(t, s.String(), s2.String())
+
+	s3 := New(comparator.Reverse(comparator.IntComparator), WithCodec[int](codec.Integer[int]{}))
+	assert.Nil(t, s3.UnmarshalBinary(data))
+	assert.Equal(t, 297, s3.First().Value())
+	assert.Equal(t, 100, s3.Size())
+
+	assert.NotNil(t, s2.UnmarshalBinary(data[:10]))
+}
+
+func TestSetJSONAndGob(t *testing.T) {
+	s := New(comparator.StringComparator)
+	s.Insert("b")
+	s.Insert("a")
+
+	data, err := json.Marshal(s)
+	assert.Nil(t, err)
+	assert.Equal(t, `["a","b"]`, string(data))
+
+	s2 := New(comparator.StringComparator)
+	assert.Nil(t, json.Unmarshal([]byte(`["c","a","c"]`), s2))
+	assert.Equal(t, "[a c]", s2.String())
+
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(s))
+	s3 := New(comparator.StringComparator)
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(s3))
+	assert.Equal(t, "[a b]", s3.String())
+}
+
+func TestSetWrongCodec(t *testing.T) {
+	s := New(comparator.IntComparator, WithCodec[string](codec.String[string]{}))
+	_, err := s.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	s.Insert(1)
+	_, err = s.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, s.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+	assert.Equal(t, 1, s.Size())
+}
@@ -0,0 +1,61 @@
+//go:build go1.23
+
+package set
+
+import "iter"
+
+// All returns an iterator over elements in the set, in ascending order
+func (s *Set[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.locker.RLock()
+		defer s.locker.RUnlock()
+
+		for node := s.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over elements in the set, in descending order
+func (s *Set[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		s.locker.RLock()
+		defer s.locker.RUnlock()
+
+		for node := s.tree.Last(); node != nil; node = node.Prev() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over elements in the MultiSet, in ascending order
+func (ms *MultiSet[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ms.locker.RLock()
+		defer ms.locker.RUnlock()
+
+		for node := ms.tree.First(); node != nil; node = node.Next() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over elements in the MultiSet, in descending order
+func (ms *MultiSet[T]) Backward() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		ms.locker.RLock()
+		defer ms.locker.RUnlock()
+
+		for node := ms.tree.Last(); node != nil; node = node.Prev() {
+			if !yield(node.Key()) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package set
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetIterSeq(t *testing.T) {
+	s := New(comparator.IntComparator, WithGoroutineSafe())
+	for i := 4; i >= 0; i-- {
+		s.Insert(i)
+	}
+
+	values := make([]int, 0)
+	for v := range s.All() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, values)
+
+	values = values[:0]
+	for v := range s.Backward() {
+		if v < 2 {
+			break
+		}
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{4, 3, 2}, values)
+}
+
+func TestMultiSetIterSeq(t *testing.T) {
+	ms := NewMultiSet(comparator.IntComparator)
+	for i := 0; i < 3; i++ {
+		ms.Insert(i)
+		ms.Insert(i)
+	}
+
+	values := make([]int, 0)
+	for v := range ms.All() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{0, 0, 1, 1, 2, 2}, values)
+
+	values = values[:0]
+	for v := range ms.Backward() {
+		values = append(values, v)
+	}
+	assert.Equal(t, []int{2, 2, 1, 1, 0, 0}, values)
+}
@@ -110,6 +110,45 @@ func (ms *MultiSet[T]) Last() *SetIterator[T] {
 	return &SetIterator[T]{node: ms.tree.Last()}
 }
 
+// At returns the iterator with the k-th (counting from 0) smallest element in the MultiSet, the iterator is invalid if k is out of range
+func (ms *MultiSet[T]) At(k int) *SetIterator[T] {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	return &SetIterator[T]{node: ms.tree.Select(k)}
+}
+
+// IndexOf returns the position (counting from 0) of the first element that is equal to the passed element in the MultiSet,
+// or -1 if the element is not in the MultiSet
+func (ms *MultiSet[T]) IndexOf(element T) int {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	if ms.tree.FindNode(element) == nil {
+		return -1
+	}
+	return ms.tree.Rank(element)
+}
+
+// Range traversals elements in range [lo, hi) in the MultiSet, it will not stop until to the end of the range or the visitor returns false
+// The visitor may erase elements from the MultiSet unless it is goroutine-safe, in which case Range holds the read lock and erasing deadlocks
+func (ms *MultiSet[T]) Range(lo, hi T, visitor visitor.Visitor[T]) {
+	ms.locker.RLock()
+	defer ms.locker.RUnlock()
+
+	ms.tree.RangeTraversal(lo, hi, func(key T, value bool) bool {
+		return visitor(key)
+	})
+}
+
+// EraseRange erases all elements in range [lo, hi) from the MultiSet, and returns the amount of erased elements
+func (ms *MultiSet[T]) EraseRange(lo, hi T) int {
+	ms.locker.Lock()
+	defer ms.locker.Unlock()
+
+	return ms.tree.DeleteRange(lo, hi)
+}
+
 // Count returns the amount of elements that are equal to the passed element in the MultiSet
 func (ms *MultiSet[T]) Count(element T) int {
 	ms.locker.RLock()
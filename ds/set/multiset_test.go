@@ -51,3 +51,55 @@ func TestMultiSet(t *testing.T) {
 	iter = mset.Find(3)
 	assert.Equal(t, 3, iter.Value())
 }
+
+func TestMultiSet_AtIndexOf(t *testing.T) {
+	mset := NewMultiSet(comparator.IntComparator)
+	for i := 0; i < 5; i++ {
+		mset.Insert(i)
+		mset.Insert(i)
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i/2, mset.At(i).Value())
+	}
+	assert.Equal(t, 6, mset.IndexOf(3))
+	assert.Equal(t, -1, mset.IndexOf(5))
+}
+
+func TestMultiSet_Range(t *testing.T) {
+	mset := NewMultiSet(comparator.IntComparator)
+	for i := 0; i < 10; i++ {
+		mset.Insert(i)
+		mset.Insert(i)
+	}
+
+	count := 0
+	mset.Range(0, 3, func(value int) bool {
+		count++
+		return count < 4
+	})
+	assert.Equal(t, 4, count)
+
+	assert.Equal(t, 6, mset.EraseRange(0, 3))
+	assert.Equal(t, "[3 3 4 4 5 5 6 6 7 7 8 8 9 9]", mset.String())
+}
+
+func TestMultiSet_RangeErase(t *testing.T) {
+	mset := NewMultiSet(comparator.IntComparator)
+	for i := 0; i < 100; i++ {
+		mset.Insert(i)
+		mset.Insert(i)
+	}
+	values := make([]int, 0)
+	mset.Range(0, 50, func(value int) bool {
+		values = append(values, value)
+		mset.Erase(value)
+		return true
+	})
+	// Erase erases one node at a time, so each element is visited twice
+	assert.Equal(t, 100, len(values))
+	for i, value := range values {
+		assert.Equal(t, i/2, value)
+	}
+	assert.Equal(t, 100, mset.Size())
+	assert.Equal(t, 50, mset.First().Value())
+}
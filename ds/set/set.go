@@ -5,6 +5,7 @@ import (
 	gosync "sync"
 
 	"github.com/liyue201/gostl/ds/rbtree"
+	"github.com/liyue201/gostl/utils/codec"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/sync"
 	"github.com/liyue201/gostl/utils/visitor"
@@ -22,6 +23,7 @@ var (
 // Options holds the Set's options
 type Options struct {
 	locker sync.Locker
+	codec  any
 }
 
 // Option is a function  type used to set Options
@@ -36,11 +38,20 @@ func WithGoroutineSafe() Option {
 	}
 }
 
+// WithCodec sets the codec used to encode elements in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of elements, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithCodec[T any](c codec.Codec[T]) Option {
+	return func(option *Options) {
+		option.codec = c
+	}
+}
+
 // Set uses RbTress for internal data structure, and every key can must bee unique.
 type Set[T any] struct {
 	tree   *rbtree.RbTree[T, bool]
 	locker sync.Locker
 	keyCmp comparator.Comparator[T]
+	codec  codec.Codec[T]
 }
 
 // New creates a new set
@@ -55,6 +66,7 @@ func New[T any](cmp comparator.Comparator[T], opts ...Option) *Set[T] {
 		tree:   rbtree.New[T, bool](cmp),
 		locker: option.locker,
 		keyCmp: cmp,
+		codec:  codec.Get[T](option.codec),
 	}
 }
 
@@ -129,6 +141,44 @@ func (s *Set[T]) Last() *SetIterator[T] {
 	return &SetIterator[T]{node: s.tree.Last()}
 }
 
+// At returns the iterator with the k-th (counting from 0) smallest element in the set, the iterator is invalid if k is out of range
+func (s *Set[T]) At(k int) *SetIterator[T] {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return &SetIterator[T]{node: s.tree.Select(k)}
+}
+
+// IndexOf returns the position (counting from 0) of the passed element in the set, or -1 if the element is not in the set
+func (s *Set[T]) IndexOf(element T) int {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	if s.tree.FindNode(element) == nil {
+		return -1
+	}
+	return s.tree.Rank(element)
+}
+
+// Range traversals elements in range [lo, hi) in the set, it will not stop until to the end of the range or the visitor returns false
+// The visitor may erase elements from the set unless it is goroutine-safe, in which case Range holds the read lock and erasing deadlocks
+func (s *Set[T]) Range(lo, hi T, visitor visitor.Visitor[T]) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	s.tree.RangeTraversal(lo, hi, func(key T, value bool) bool {
+		return visitor(key)
+	})
+}
+
+// EraseRange erases all elements in range [lo, hi) from the set, and returns the amount of erased elements
+func (s *Set[T]) EraseRange(lo, hi T) int {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	return s.tree.DeleteRange(lo, hi)
+}
+
 // Clear clears the set
 func (s *Set[T]) Clear() {
 	s.locker.Lock()
@@ -188,7 +238,7 @@ func (s *Set[T]) Intersect(other *Set[T]) *Set[T] {
 	s.locker.RLock()
 	defer s.locker.RUnlock()
 
-	set := New(s.keyCmp)
+	set := New(s.keyCmp, WithCodec(s.codec))
 	sIter := s.tree.IterFirst()
 	otherIter := other.tree.IterFirst()
 	for sIter.IsValid() && otherIter.IsValid() {
@@ -212,7 +262,7 @@ func (s *Set[T]) Union(other *Set[T]) *Set[T] {
 	s.locker.RLock()
 	defer s.locker.RUnlock()
 
-	set := New(s.keyCmp)
+	set := New(s.keyCmp, WithCodec(s.codec))
 	sIter := s.tree.IterFirst()
 	otherIter := other.tree.IterFirst()
 	for sIter.IsValid() && otherIter.IsValid() {
@@ -244,7 +294,7 @@ func (s *Set[T]) Diff(other *Set[T]) *Set[T] {
 	s.locker.RLock()
 	defer s.locker.RUnlock()
 
-	set := New(s.keyCmp)
+	set := New(s.keyCmp, WithCodec(s.codec))
 	sIter := s.tree.IterFirst()
 	otherIter := other.tree.IterFirst()
 	for sIter.IsValid() && otherIter.IsValid() {
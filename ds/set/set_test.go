@@ -83,3 +83,54 @@ func TestSetIterator(t *testing.T) {
 	s.Clear()
 	assert.Equal(t, 0, s.Size())
 }
+
+func TestSet_AtIndexOf(t *testing.T) {
+	s := New(comparator.IntComparator)
+	for i := 9; i >= 0; i-- {
+		s.Insert(i * 2)
+	}
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, i*2, s.At(i).Value())
+		assert.Equal(t, i, s.IndexOf(i*2))
+	}
+	assert.False(t, s.At(10).IsValid())
+	assert.Equal(t, -1, s.IndexOf(3))
+}
+
+func TestSet_Range(t *testing.T) {
+	s := New(comparator.IntComparator, WithGoroutineSafe())
+	for i := 0; i < 100; i++ {
+		s.Insert(i)
+	}
+
+	values := make([]int, 0)
+	s.Range(95, 200, func(value int) bool {
+		values = append(values, value)
+		return true
+	})
+	assert.Equal(t, []int{95, 96, 97, 98, 99}, values)
+
+	assert.Equal(t, 50, s.EraseRange(25, 75))
+	assert.Equal(t, 50, s.Size())
+	assert.Equal(t, 75, s.LowerBound(25).Value())
+}
+
+func TestSet_RangeErase(t *testing.T) {
+	s := New(comparator.IntComparator)
+	for i := 0; i < 100; i++ {
+		s.Insert(i)
+	}
+	values := make([]int, 0)
+	s.Range(0, 100, func(value int) bool {
+		values = append(values, value)
+		if value%3 != 0 {
+			s.Erase(value)
+		}
+		return true
+	})
+	assert.Equal(t, 100, len(values))
+	for i, value := range values {
+		assert.Equal(t, i, value)
+	}
+	assert.Equal(t, 34, s.Size())
+}
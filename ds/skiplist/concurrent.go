@@ -0,0 +1,298 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/visitor"
+	"math/rand"
+	gosync "sync"
+	"sync/atomic"
+	"time"
+)
+
+// markRef is an immutable pair of a next pointer and a deletion mark, it is replaced as a whole by CAS.
+// A node is logically deleted once its markRef at level 0 is marked
+type markRef[K, V any] struct {
+	node   *concurrentNode[K, V]
+	marked bool
+}
+
+// concurrentNode is a node of ConcurrentSkiplist
+type concurrentNode[K, V any] struct {
+	key   K
+	value atomic.Value   // *V
+	next  []atomic.Value // *markRef[K, V]
+}
+
+func newConcurrentNode[K, V any](key K, value V, level int) *concurrentNode[K, V] {
+	n := &concurrentNode[K, V]{key: key, next: make([]atomic.Value, level)}
+	n.value.Store(&value)
+	return n
+}
+
+func (n *concurrentNode[K, V]) loadValue() V {
+	return *n.value.Load().(*V)
+}
+
+func (n *concurrentNode[K, V]) loadNext(level int) *markRef[K, V] {
+	return n.next[level].Load().(*markRef[K, V])
+}
+
+func (n *concurrentNode[K, V]) casNext(level int, old *markRef[K, V], next *concurrentNode[K, V], marked bool) bool {
+	return n.next[level].CompareAndSwap(old, &markRef[K, V]{node: next, marked: marked})
+}
+
+// ConcurrentSkiplist is a lock-free skiplist, it uses CAS on the next pointers and marks nodes as deleted
+// before unlinking them, just like Java's ConcurrentSkipListMap. All operations are goroutine-safe without any lock.
+// Traversal and Range are weakly consistent: they never visit a key twice, and they reflect some (maybe not all)
+// of the modifications made after they start
+type ConcurrentSkiplist[K, V any] struct {
+	head     *concurrentNode[K, V]
+	maxLevel int
+	keyCmp   comparator.Comparator[K]
+	len      int64
+	randPool gosync.Pool
+}
+
+// NewConcurrent creates a ConcurrentSkiplist, only the WithMaxLevel option takes effect
+func NewConcurrent[K, V any](cmp comparator.Comparator[K], opts ...Option) *ConcurrentSkiplist[K, V] {
+	option := Options{
+		maxLevel: defaultMaxLevel,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	l := &ConcurrentSkiplist[K, V]{
+		head:     &concurrentNode[K, V]{next: make([]atomic.Value, option.maxLevel)},
+		maxLevel: option.maxLevel,
+		keyCmp:   cmp,
+	}
+	for i := range l.head.next {
+		l.head.next[i].Store(&markRef[K, V]{})
+	}
+	l.randPool.New = func() any {
+		return rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	return l
+}
+
+// Insert inserts a key-value pair into the skiplist, the value is replaced if the key is already in the skiplist
+func (sl *ConcurrentSkiplist[K, V]) Insert(key K, value V) {
+	level := sl.randomLevel()
+	preds := make([]*concurrentNode[K, V], sl.maxLevel)
+	predRefs := make([]*markRef[K, V], sl.maxLevel)
+	succs := make([]*concurrentNode[K, V], sl.maxLevel)
+	for {
+		if sl.find(key, preds, predRefs, succs) {
+			found := succs[0]
+			if found.loadNext(0).marked {
+				// the node is being removed, wait for it to be unlinked
+				continue
+			}
+			found.value.Store(&value)
+			return
+		}
+
+		n := newConcurrentNode(key, value, level)
+		for i := 0; i < level; i++ {
+			n.next[i].Store(&markRef[K, V]{node: succs[i]})
+		}
+		if !preds[0].next[0].CompareAndSwap(predRefs[0], &markRef[K, V]{node: n}) {
+			continue
+		}
+		atomic.AddInt64(&sl.len, 1)
+		sl.linkUpperLevels(n, level, preds, predRefs, succs)
+		return
+	}
+}
+
+// linkUpperLevels links the node n which has been linked at level 0 at the other levels
+func (sl *ConcurrentSkiplist[K, V]) linkUpperLevels(n *concurrentNode[K, V], level int, preds []*concurrentNode[K, V],
+	predRefs []*markRef[K, V], succs []*concurrentNode[K, V]) {
+	for i := 1; i < level; i++ {
+		for {
+			ref := n.loadNext(i)
+			if ref.marked {
+				// n is being removed, stop linking it
+				return
+			}
+			if ref.node != succs[i] && !n.casNext(i, ref, succs[i], false) {
+				continue
+			}
+			if preds[i].next[i].CompareAndSwap(predRefs[i], &markRef[K, V]{node: n}) {
+				break
+			}
+			sl.find(n.key, preds, predRefs, succs)
+		}
+	}
+}
+
+// Get returns the value associated with the passed key if the key is in the skiplist, otherwise returns error
+func (sl *ConcurrentSkiplist[K, V]) Get(key K) (V, error) {
+	n := sl.lowerBound(key)
+	if n != nil && sl.keyCmp(n.key, key) == 0 {
+		return n.loadValue(), nil
+	}
+	return *new(V), ErrorNotFound
+}
+
+// Remove removes the key-value pair associated with the passed key and returns true if the key is in the skiplist, otherwise returns false
+func (sl *ConcurrentSkiplist[K, V]) Remove(key K) bool {
+	preds := make([]*concurrentNode[K, V], sl.maxLevel)
+	predRefs := make([]*markRef[K, V], sl.maxLevel)
+	succs := make([]*concurrentNode[K, V], sl.maxLevel)
+	if !sl.find(key, preds, predRefs, succs) {
+		return false
+	}
+	n := succs[0]
+
+	// mark the upper levels first, so that no more nodes are linked after n
+	for i := len(n.next) - 1; i > 0; i-- {
+		for {
+			ref := n.loadNext(i)
+			if ref.marked || n.casNext(i, ref, ref.node, true) {
+				break
+			}
+		}
+	}
+	for {
+		ref := n.loadNext(0)
+		if ref.marked {
+			// removed by another goroutine
+			return false
+		}
+		if n.casNext(0, ref, ref.node, true) {
+			atomic.AddInt64(&sl.len, -1)
+			// unlink n
+			sl.find(key, preds, predRefs, succs)
+			return true
+		}
+	}
+}
+
+// Len returns the amount of key-value pair in the skiplist
+func (sl *ConcurrentSkiplist[K, V]) Len() int {
+	return int(atomic.LoadInt64(&sl.len))
+}
+
+// Traversal traversals elements in the skiplist, it will stop until to the end or the visitor returns false
+func (sl *ConcurrentSkiplist[K, V]) Traversal(visitor visitor.KvVisitor[K, V]) {
+	sl.traversalFrom(sl.head.loadNext(0).node, visitor)
+}
+
+// Range traversals elements that their keys are in range [lo, hi) in the skiplist,
+// it will not stop until to the end of the range or the visitor returns false
+func (sl *ConcurrentSkiplist[K, V]) Range(lo, hi K, visitor visitor.KvVisitor[K, V]) {
+	sl.traversalFrom(sl.lowerBound(lo), func(key K, value V) bool {
+		if sl.keyCmp(key, hi) >= 0 {
+			return false
+		}
+		return visitor(key, value)
+	})
+}
+
+// Keys returns all keys in the skiplist
+func (sl *ConcurrentSkiplist[K, V]) Keys() []K {
+	var keys []K
+	sl.Traversal(func(key K, value V) bool {
+		keys = append(keys, key)
+		return true
+	})
+	return keys
+}
+
+func (sl *ConcurrentSkiplist[K, V]) traversalFrom(n *concurrentNode[K, V], visitor visitor.KvVisitor[K, V]) {
+	for n != nil {
+		ref := n.loadNext(0)
+		if !ref.marked && !visitor(n.key, n.loadValue()) {
+			return
+		}
+		n = ref.node
+	}
+}
+
+// find finds the predecessors and successors of key at each level, and unlinks the marked nodes on the way.
+// predRefs[i] is the unmarked markRef of preds[i] at level i which points to succs[i], so a CAS against it
+// fails once preds[i] is removed.
+// It returns true if succs[0] has the passed key
+func (sl *ConcurrentSkiplist[K, V]) find(key K, preds []*concurrentNode[K, V], predRefs []*markRef[K, V], succs []*concurrentNode[K, V]) bool {
+retry:
+	for {
+		pred := sl.head
+		var curr *concurrentNode[K, V]
+		for i := sl.maxLevel - 1; i >= 0; i-- {
+			predRef := pred.loadNext(i)
+			if predRef.marked {
+				// pred is removed after it was reached at the upper level
+				continue retry
+			}
+			curr = predRef.node
+			for curr != nil {
+				currRef := curr.loadNext(i)
+				for currRef.marked {
+					// curr is removed, unlink it
+					if predRef.marked || !pred.casNext(i, predRef, currRef.node, false) {
+						continue retry
+					}
+					predRef = pred.loadNext(i)
+					if predRef.marked {
+						continue retry
+					}
+					curr = predRef.node
+					if curr == nil {
+						break
+					}
+					currRef = curr.loadNext(i)
+				}
+				if curr == nil || sl.keyCmp(curr.key, key) >= 0 {
+					break
+				}
+				pred = curr
+				predRef = currRef
+				curr = currRef.node
+			}
+			preds[i] = pred
+			predRefs[i] = predRef
+			succs[i] = curr
+		}
+		return curr != nil && sl.keyCmp(curr.key, key) == 0
+	}
+}
+
+// lowerBound returns the first node which is not removed and its key is equal to or greater than the passed key,
+// it doesn't modify the skiplist
+func (sl *ConcurrentSkiplist[K, V]) lowerBound(key K) *concurrentNode[K, V] {
+	pred := sl.head
+	var curr *concurrentNode[K, V]
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		curr = pred.loadNext(i).node
+		for curr != nil {
+			currRef := curr.loadNext(i)
+			for currRef.marked && currRef.node != nil {
+				curr = currRef.node
+				currRef = curr.loadNext(i)
+			}
+			if currRef.marked {
+				// the last node is removed
+				curr = nil
+				break
+			}
+			if sl.keyCmp(curr.key, key) >= 0 {
+				break
+			}
+			pred = curr
+			curr = currRef.node
+		}
+	}
+	return curr
+}
+
+func (sl *ConcurrentSkiplist[K, V]) randomLevel() int {
+	rander := sl.randPool.Get().(*rand.Rand)
+	defer sl.randPool.Put(rander)
+
+	level := 1
+	for level < sl.maxLevel && rander.Int63()&1 == 0 {
+		level++
+	}
+	return level
+}
@@ -0,0 +1,207 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestConcurrentSkiplist(t *testing.T) {
+	list := NewConcurrent[int, int](comparator.IntComparator, WithMaxLevel(5))
+
+	m := make(map[int]int)
+	for i := 0; i < 1000; i++ {
+		key := rand.Int() % 500
+		list.Insert(key, i)
+		m[key] = i
+	}
+	assert.Equal(t, len(m), list.Len())
+	for key, v := range m {
+		ret, err := list.Get(key)
+		assert.Nil(t, err)
+		assert.Equal(t, v, ret)
+	}
+
+	for i := 0; i < 300; i++ {
+		key := rand.Int() % 500
+		_, ok := m[key]
+		assert.Equal(t, ok, list.Remove(key))
+		delete(m, key)
+		_, err := list.Get(key)
+		assert.Equal(t, ErrorNotFound, err)
+	}
+	assert.Equal(t, len(m), list.Len())
+
+	prev := -1
+	list.Traversal(func(key, value int) bool {
+		assert.Less(t, prev, key)
+		assert.Equal(t, m[key], value)
+		prev = key
+		return true
+	})
+	assert.Equal(t, len(m), len(list.Keys()))
+}
+
+func TestConcurrentSkiplist_Range(t *testing.T) {
+	list := NewConcurrent[int, int](comparator.IntComparator)
+	for i := 0; i < 100; i += 2 {
+		list.Insert(i, i*10)
+	}
+	var keys []int
+	list.Range(11, 21, func(key, value int) bool {
+		assert.Equal(t, key*10, value)
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{12, 14, 16, 18, 20}, keys)
+
+	keys = nil
+	list.Range(0, 100, func(key, value int) bool {
+		keys = append(keys, key)
+		return len(keys) < 3
+	})
+	assert.Equal(t, []int{0, 2, 4}, keys)
+}
+
+func TestConcurrentSkiplist_ParallelInsertRemove(t *testing.T) {
+	list := NewConcurrent[int, int](comparator.IntComparator)
+	const workers = 8
+	const n = 2000
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < n; i++ {
+				list.Insert(i*workers+w, w)
+			}
+			// remove odd keys of this worker
+			for i := 1; i < n; i += 2 {
+				assert.True(t, list.Remove(i*workers+w))
+			}
+		}(w)
+	}
+	wg.Wait()
+
+	assert.Equal(t, workers*n/2, list.Len())
+	prev := -1
+	count := 0
+	list.Traversal(func(key, value int) bool {
+		assert.Less(t, prev, key)
+		assert.Equal(t, 0, (key/workers)%2)
+		assert.Equal(t, key%workers, value)
+		prev = key
+		count++
+		return true
+	})
+	assert.Equal(t, workers*n/2, count)
+}
+
+// TestConcurrentSkiplist_RemovePredDuringInsert removes the predecessor found at the upper level while Insert
+// is still in find, the insert must not be linked behind the removed node
+func TestConcurrentSkiplist_RemovePredDuringInsert(t *testing.T) {
+	var list *ConcurrentSkiplist[int, int]
+	hook := false
+	list = NewConcurrent[int, int](func(a, b int) int {
+		if hook && a == 0 && b == 1 {
+			hook = false
+			assert.True(t, list.Remove(0))
+		}
+		return comparator.IntComparator(a, b)
+	}, WithMaxLevel(2))
+
+	// make node 0 reach level 2
+	for {
+		list.Insert(0, 0)
+		if list.head.loadNext(1).node != nil {
+			break
+		}
+		list.Remove(0)
+	}
+
+	hook = true
+	list.Insert(1, 1)
+	assert.False(t, hook)
+
+	_, err := list.Get(0)
+	assert.Equal(t, ErrorNotFound, err)
+	v, err := list.Get(1)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, v)
+	assert.Equal(t, 1, list.Len())
+	assert.Equal(t, []int{1}, list.Keys())
+}
+
+func TestConcurrentSkiplist_Stress(t *testing.T) {
+	list := NewConcurrent[int, int](comparator.IntComparator)
+	const keyRange = 256
+	const workers = 8
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	// readers check that traversal is always sorted and never repeats a key
+	for r := 0; r < 2; r++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				select {
+				case <-stop:
+					return
+				default:
+				}
+				prev := -1
+				list.Range(0, keyRange, func(key, value int) bool {
+					if key <= prev {
+						t.Errorf("keys are out of order: %v after %v", key, prev)
+						return false
+					}
+					prev = key
+					return true
+				})
+			}
+		}()
+	}
+
+	var writers sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		writers.Add(1)
+		go func(seed int64) {
+			defer writers.Done()
+			r := rand.New(rand.NewSource(seed))
+			for i := 0; i < 5000; i++ {
+				key := r.Intn(keyRange)
+				switch r.Intn(3) {
+				case 0:
+					list.Insert(key, key)
+				case 1:
+					list.Remove(key)
+				default:
+					if v, err := list.Get(key); err == nil {
+						assert.Equal(t, key, v)
+					}
+				}
+			}
+		}(int64(w))
+	}
+	writers.Wait()
+	close(stop)
+	wg.Wait()
+
+	// the list must be consistent once all goroutines are done
+	count := 0
+	list.Traversal(func(key, value int) bool {
+		count++
+		return true
+	})
+	assert.Equal(t, count, list.Len())
+	for i := 0; i < keyRange; i++ {
+		_, err := list.Get(i)
+		assert.Equal(t, err == nil, list.Remove(i))
+	}
+	assert.Equal(t, 0, list.Len())
+	assert.Nil(t, list.Keys())
+}
@@ -0,0 +1,150 @@
+package skiplist
+
+import (
+	"encoding/json"
+
+	"github.com/liyue201/gostl/utils/codec"
+)
+
+// jsonEntry is the JSON representation of a key-value pair
+type jsonEntry[K, V any] struct {
+	Key   K `json:"key"`
+	Value V `json:"value"`
+}
+
+// MarshalBinary encodes the skiplist with its key and value codecs, it implements encoding.BinaryMarshaler
+func (sl *Skiplist[K, V]) MarshalBinary() ([]byte, error) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	if err := codec.Check(sl.keyCodec, sl.valueCodec); err != nil {
+		return nil, err
+	}
+	var err error
+	buf := codec.AppendUvarint(nil, uint64(sl.len))
+	for e := sl.head.next[0]; e != nil; e = e.next[0] {
+		if buf, err = sl.keyCodec.Encode(buf, e.key); err != nil {
+			return nil, err
+		}
+		if buf, err = sl.valueCodec.Encode(buf, e.value); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the skiplist with the data returned by MarshalBinary,
+// it implements encoding.BinaryUnmarshaler. Note that the skiplist must be created by New with a comparator
+func (sl *Skiplist[K, V]) UnmarshalBinary(data []byte) error {
+	if err := codec.Check(sl.keyCodec, sl.valueCodec); err != nil {
+		return err
+	}
+	n, data, err := codec.ReadLen(data)
+	if err != nil {
+		return err
+	}
+	keys := make([]K, n)
+	values := make([]V, n)
+	for i := 0; i < n; i++ {
+		if keys[i], data, err = sl.keyCodec.Decode(data); err != nil {
+			return err
+		}
+		if values[i], data, err = sl.valueCodec.Decode(data); err != nil {
+			return err
+		}
+	}
+	if len(data) != 0 {
+		return codec.ErrorCorruptData
+	}
+
+	sl.locker.Lock()
+	defer sl.locker.Unlock()
+
+	sl.build(keys, values)
+	return nil
+}
+
+// MarshalJSON encodes the skiplist into a JSON array of key-value objects, it implements json.Marshaler
+func (sl *Skiplist[K, V]) MarshalJSON() ([]byte, error) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	entries := make([]jsonEntry[K, V], 0, sl.len)
+	for e := sl.head.next[0]; e != nil; e = e.next[0] {
+		entries = append(entries, jsonEntry[K, V]{Key: e.key, Value: e.value})
+	}
+	return json.Marshal(entries)
+}
+
+// UnmarshalJSON replaces the content of the skiplist with the data returned by MarshalJSON, it implements json.Unmarshaler.
+// Note that the skiplist must be created by New with a comparator
+func (sl *Skiplist[K, V]) UnmarshalJSON(data []byte) error {
+	var entries []jsonEntry[K, V]
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	keys := make([]K, len(entries))
+	values := make([]V, len(entries))
+	for i := range entries {
+		keys[i] = entries[i].Key
+		values[i] = entries[i].Value
+	}
+
+	sl.locker.Lock()
+	defer sl.locker.Unlock()
+
+	sl.build(keys, values)
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, it is the same as MarshalBinary
+func (sl *Skiplist[K, V]) GobEncode() ([]byte, error) {
+	return sl.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, it is the same as UnmarshalBinary
+func (sl *Skiplist[K, V]) GobDecode(data []byte) error {
+	return sl.UnmarshalBinary(data)
+}
+
+// build replaces the content of the skiplist with the keys and values, it takes O(n) time if the keys are
+// strictly increasing wrt the skiplist's comparator, otherwise the key-value pairs are inserted one by one
+func (sl *Skiplist[K, V]) build(keys []K, values []V) {
+	sl.clear()
+	for i := 1; i < len(keys); i++ {
+		if sl.keyCmp(keys[i-1], keys[i]) >= 0 {
+			for j := range keys {
+				sl.insert(keys[j], values[j])
+			}
+			return
+		}
+	}
+
+	// append the elements to the tails of each level
+	tails := make([]*Node[K, V], sl.maxLevel)
+	tailRanks := make([]int, sl.maxLevel)
+	for i := range tails {
+		tails[i] = &sl.head
+	}
+	for i := range keys {
+		level := sl.randomLevel()
+		e := &Element[K, V]{
+			key:   keys[i],
+			value: values[i],
+			Node: Node[K, V]{
+				next: make([]*Element[K, V], level),
+				span: make([]int, level),
+			},
+		}
+		for l := range e.next {
+			tails[l].next[l] = e
+			tails[l].span[l] = i + 1 - tailRanks[l]
+			tails[l] = &e.Node
+			tailRanks[l] = i + 1
+		}
+	}
+	for l, tail := range tails {
+		tail.span[l] = len(keys) - tailRanks[l]
+	}
+	sl.len = len(keys)
+}
@@ -0,0 +1,76 @@
+package skiplist
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkiplistBinary(t *testing.T) {
+	sl := New[int, float64](comparator.IntComparator, WithKeyCodec[int](codec.Integer[int]{}), WithValueCodec[float64](codec.Float[float64]{}))
+	for i := 0; i < 1000; i++ {
+		sl.Insert(i, float64(i)/2)
+	}
+	data, err := sl.MarshalBinary()
+	assert.Nil(t, err)
+
+	sl2 := New[int, float64](comparator.IntComparator, WithKeyCodec[int](codec.Integer[int]{}), WithValueCodec[float64](codec.Float[float64]{}))
+	sl2.Insert(-1, 0)
+	assert.Nil(t, sl2.UnmarshalBinary(data))
+	assert.Equal(t, 1000, sl2.Len())
+	assert.Equal(t, sl.Keys(), sl2.Keys())
+	for i := 0; i < 1000; i++ {
+		v, err := sl2.Get(i)
+		assert.Nil(t, err)
+		assert.Equal(t, float64(i)/2, v)
+	}
+	sl2.Insert(2000, 1)
+	assert.True(t, sl2.Remove(500))
+	assert.Equal(t, 1000, sl2.Len())
+
+	sl3 := New[int, float64](comparator.Reverse(comparator.IntComparator), WithKeyCodec[int](codec.Integer[int]{}), WithValueCodec[float64](codec.Float[float64]{}))
+	assert.Nil(t, sl3.UnmarshalBinary(data))
+	assert.Equal(t, 999, sl3.Keys()[0])
+
+	assert.NotNil(t, sl2.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestSkiplistJSONAndGob(t *testing.T) {
+	sl := New[string, int](comparator.StringComparator)
+	sl.Insert("b", 2)
+	sl.Insert("a", 1)
+
+	data, err := json.Marshal(sl)
+	assert.Nil(t, err)
+	assert.Equal(t, `[{"key":"a","value":1},{"key":"b","value":2}]`, string(data))
+
+	sl2 := New[string, int](comparator.StringComparator)
+	assert.Nil(t, json.Unmarshal(data, sl2))
+	assert.Equal(t, []string{"a", "b"}, sl2.Keys())
+
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(sl))
+	sl3 := New[string, int](comparator.StringComparator)
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(sl3))
+	v, _ := sl3.Get("b")
+	assert.Equal(t, 2, v)
+}
+
+func TestSkiplistWrongCodec(t *testing.T) {
+	sl := New[int, float64](comparator.IntComparator, WithKeyCodec[string](codec.String[string]{}))
+	_, err := sl.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, sl.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+
+	sl = New[int, float64](comparator.IntComparator, WithValueCodec[int](codec.Integer[int]{}))
+	sl.Insert(1, 0.5)
+	_, err = sl.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, sl.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+	assert.Equal(t, 1, sl.Len())
+}
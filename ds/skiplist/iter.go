@@ -0,0 +1,55 @@
+//go:build go1.23
+
+package skiplist
+
+import "iter"
+
+// All returns an iterator over key-value pairs in the skiplist, in ascending order of keys
+func (sl *Skiplist[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.locker.RLock()
+		defer sl.locker.RUnlock()
+
+		for e := sl.head.next[0]; e != nil; e = e.next[0] {
+			if !yield(e.key, e.value) {
+				return
+			}
+		}
+	}
+}
+
+// IterKeys returns an iterator over keys in the skiplist, in ascending order
+func (sl *Skiplist[K, V]) IterKeys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		sl.locker.RLock()
+		defer sl.locker.RUnlock()
+
+		for e := sl.head.next[0]; e != nil; e = e.next[0] {
+			if !yield(e.key) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the skiplist, in ascending order of keys
+func (sl *Skiplist[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		sl.locker.RLock()
+		defer sl.locker.RUnlock()
+
+		for e := sl.head.next[0]; e != nil; e = e.next[0] {
+			if !yield(e.value) {
+				return
+			}
+		}
+	}
+}
+
+// All returns an iterator over key-value pairs in the ConcurrentSkiplist, in ascending order of keys.
+// Like Traversal, it is weakly consistent
+func (sl *ConcurrentSkiplist[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		sl.Traversal(yield)
+	}
+}
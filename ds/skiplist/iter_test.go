@@ -0,0 +1,54 @@
+//go:build go1.23
+
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkiplistIterSeq(t *testing.T) {
+	sl := New[int, int](comparator.IntComparator, WithGoroutineSafe())
+	for i := 9; i >= 0; i-- {
+		sl.Insert(i, i*10)
+	}
+
+	n := 0
+	for k, v := range sl.All() {
+		assert.Equal(t, n, k)
+		assert.Equal(t, k*10, v)
+		n++
+	}
+	assert.Equal(t, 10, n)
+
+	keys := make([]int, 0)
+	for k := range sl.IterKeys() {
+		if k == 3 {
+			break
+		}
+		keys = append(keys, k)
+	}
+	assert.Equal(t, []int{0, 1, 2}, keys)
+
+	sum := 0
+	for v := range sl.Values() {
+		sum += v
+	}
+	assert.Equal(t, 450, sum)
+}
+
+func TestConcurrentSkiplistAll(t *testing.T) {
+	sl := NewConcurrent[int, int](comparator.IntComparator)
+	for i := 0; i < 10; i++ {
+		sl.Insert(i, i*10)
+	}
+	n := 0
+	for k, v := range sl.All() {
+		assert.Equal(t, n, k)
+		assert.Equal(t, k*10, v)
+		n++
+	}
+	assert.Equal(t, 10, n)
+}
@@ -0,0 +1,54 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/iterator"
+)
+
+var _ iterator.KvIterator[int, int] = (*SkiplistIterator[int, int])(nil)
+
+// SkiplistIterator is a forward iterator implementation of Skiplist
+type SkiplistIterator[K, V any] struct {
+	element *Element[K, V]
+}
+
+// IsValid returns true if the iterator is valid, otherwise returns false
+func (iter *SkiplistIterator[K, V]) IsValid() bool {
+	return iter.element != nil
+}
+
+// Next moves the pointer of the iterator to the next element, and returns itself
+func (iter *SkiplistIterator[K, V]) Next() iterator.ConstIterator[V] {
+	if iter.IsValid() {
+		iter.element = iter.element.next[0]
+	}
+	return iter
+}
+
+// Key returns the element's key of the iterator point to
+func (iter *SkiplistIterator[K, V]) Key() K {
+	return iter.element.key
+}
+
+// Value returns the element's value of the iterator point to
+func (iter *SkiplistIterator[K, V]) Value() V {
+	return iter.element.value
+}
+
+// SetValue sets the element's value of the iterator point to
+func (iter *SkiplistIterator[K, V]) SetValue(value V) {
+	iter.element.value = value
+}
+
+// Clone clones the iterator to a new SkiplistIterator
+func (iter *SkiplistIterator[K, V]) Clone() iterator.ConstIterator[V] {
+	return &SkiplistIterator[K, V]{element: iter.element}
+}
+
+// Equal returns true if the iterator is equal to the passed iterator, otherwise returns false
+func (iter *SkiplistIterator[K, V]) Equal(other iterator.ConstIterator[V]) bool {
+	otherIter, ok := other.(*SkiplistIterator[K, V])
+	if !ok {
+		return false
+	}
+	return otherIter.element == iter.element
+}
@@ -0,0 +1,138 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// First returns the iterator with the minimum key in the skiplist
+func (sl *Skiplist[K, V]) First() *SkiplistIterator[K, V] {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	return &SkiplistIterator[K, V]{element: sl.head.next[0]}
+}
+
+// Last returns the iterator with the maximum key in the skiplist
+func (sl *Skiplist[K, V]) Last() *SkiplistIterator[K, V] {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	return &SkiplistIterator[K, V]{element: sl.last()}
+}
+
+// LowerBound returns the iterator with the first key that is equal to or greater than the passed key in the skiplist
+func (sl *Skiplist[K, V]) LowerBound(key K) *SkiplistIterator[K, V] {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	_, next := sl.search(key, false)
+	return &SkiplistIterator[K, V]{element: next}
+}
+
+// UpperBound returns the iterator with the first key that is greater than the passed key in the skiplist
+func (sl *Skiplist[K, V]) UpperBound(key K) *SkiplistIterator[K, V] {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	_, next := sl.search(key, true)
+	return &SkiplistIterator[K, V]{element: next}
+}
+
+// Floor returns the greatest key-value pair that the key is equal to or less than the passed key,
+// returns ErrorNotFound if there is no such key
+func (sl *Skiplist[K, V]) Floor(key K) (K, V, error) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	prev, _ := sl.search(key, true)
+	if prev == nil {
+		return *new(K), *new(V), ErrorNotFound
+	}
+	return prev.key, prev.value, nil
+}
+
+// Ceiling returns the least key-value pair that the key is equal to or greater than the passed key,
+// returns ErrorNotFound if there is no such key
+func (sl *Skiplist[K, V]) Ceiling(key K) (K, V, error) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	_, next := sl.search(key, false)
+	if next == nil {
+		return *new(K), *new(V), ErrorNotFound
+	}
+	return next.key, next.value, nil
+}
+
+// Range traversals elements that their keys are in range [lo, hi) in the skiplist,
+// it will not stop until to the end of the range or the visitor returns false
+func (sl *Skiplist[K, V]) Range(lo, hi K, visitor visitor.KvVisitor[K, V]) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	_, e := sl.search(lo, false)
+	for ; e != nil && sl.keyCmp(e.key, hi) < 0; e = e.next[0] {
+		if !visitor(e.key, e.value) {
+			return
+		}
+	}
+}
+
+// PopFront removes the key-value pair with the minimum key in the skiplist and returns it,
+// returns ErrorEmpty if the skiplist is empty
+func (sl *Skiplist[K, V]) PopFront() (K, V, error) {
+	sl.locker.Lock()
+	defer sl.locker.Unlock()
+
+	e := sl.head.next[0]
+	if e == nil {
+		return *new(K), *new(V), ErrorEmpty
+	}
+	sl.remove(e.key)
+	return e.key, e.value, nil
+}
+
+// PopBack removes the key-value pair with the maximum key in the skiplist and returns it,
+// returns ErrorEmpty if the skiplist is empty
+func (sl *Skiplist[K, V]) PopBack() (K, V, error) {
+	sl.locker.Lock()
+	defer sl.locker.Unlock()
+
+	e := sl.last()
+	if e == nil {
+		return *new(K), *new(V), ErrorEmpty
+	}
+	sl.remove(e.key)
+	return e.key, e.value, nil
+}
+
+// search returns the last element that its key is less than the passed key (or equal to if inclusive is true),
+// and the element next to it. It doesn't use prevNodesCache, so it can be called with a read lock
+func (sl *Skiplist[K, V]) search(key K, inclusive bool) (*Element[K, V], *Element[K, V]) {
+	var prev *Element[K, V]
+	node := &sl.head
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		for next := node.next[i]; next != nil; next = next.next[i] {
+			cmp := sl.keyCmp(next.key, key)
+			if cmp > 0 || (cmp == 0 && !inclusive) {
+				break
+			}
+			prev = next
+			node = &next.Node
+		}
+	}
+	return prev, node.next[0]
+}
+
+// last returns the element with the maximum key in the skiplist
+func (sl *Skiplist[K, V]) last() *Element[K, V] {
+	var last *Element[K, V]
+	node := &sl.head
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		for next := node.next[i]; next != nil; next = next.next[i] {
+			last = next
+			node = &next.Node
+		}
+	}
+	return last
+}
@@ -0,0 +1,98 @@
+package skiplist
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkiplistNavigation(t *testing.T) {
+	sl := New[int, int](comparator.IntComparator, WithGoroutineSafe())
+	assert.False(t, sl.First().IsValid())
+	assert.False(t, sl.Last().IsValid())
+	_, _, err := sl.Floor(1)
+	assert.Equal(t, ErrorNotFound, err)
+
+	for i := 0; i < 100; i++ {
+		sl.Insert(i*2, i)
+	}
+
+	assert.Equal(t, 0, sl.First().Key())
+	assert.Equal(t, 198, sl.Last().Key())
+
+	assert.Equal(t, 10, sl.LowerBound(10).Key())
+	assert.Equal(t, 12, sl.LowerBound(11).Key())
+	assert.Equal(t, 12, sl.UpperBound(10).Key())
+	assert.False(t, sl.LowerBound(199).IsValid())
+	assert.Equal(t, 0, sl.UpperBound(-1).Key())
+
+	k, v, err := sl.Floor(11)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, k)
+	assert.Equal(t, 5, v)
+	k, _, _ = sl.Floor(10)
+	assert.Equal(t, 10, k)
+	_, _, err = sl.Floor(-1)
+	assert.Equal(t, ErrorNotFound, err)
+
+	k, _, _ = sl.Ceiling(11)
+	assert.Equal(t, 12, k)
+	k, _, _ = sl.Ceiling(12)
+	assert.Equal(t, 12, k)
+	_, _, err = sl.Ceiling(199)
+	assert.Equal(t, ErrorNotFound, err)
+
+	keys := make([]int, 0)
+	sl.Range(5, 15, func(key, value int) bool {
+		keys = append(keys, key)
+		return true
+	})
+	assert.Equal(t, []int{6, 8, 10, 12, 14}, keys)
+
+	i := 0
+	for iter := sl.First(); iter.IsValid(); iter.Next() {
+		assert.Equal(t, i*2, iter.Key())
+		assert.Equal(t, i, iter.Value())
+		i++
+	}
+	assert.Equal(t, 100, i)
+
+	iter := sl.LowerBound(50)
+	iter.SetValue(-1)
+	v, _ = sl.Get(50)
+	assert.Equal(t, -1, v)
+	clone := iter.Clone()
+	assert.True(t, clone.Equal(iter))
+	clone.Next()
+	assert.False(t, clone.Equal(iter))
+}
+
+func TestSkiplistPop(t *testing.T) {
+	sl := New[int, int](comparator.IntComparator)
+	for i := 0; i < 100; i++ {
+		sl.Insert(i, i*10)
+	}
+
+	for i := 0; i < 50; i++ {
+		k, v, err := sl.PopFront()
+		assert.Nil(t, err)
+		assert.Equal(t, i, k)
+		assert.Equal(t, i*10, v)
+
+		k, v, err = sl.PopBack()
+		assert.Nil(t, err)
+		assert.Equal(t, 99-i, k)
+		assert.Equal(t, (99-i)*10, v)
+		assert.Equal(t, 98-i*2, sl.Len())
+	}
+
+	_, _, err := sl.PopFront()
+	assert.Equal(t, ErrorEmpty, err)
+	_, _, err = sl.PopBack()
+	assert.Equal(t, ErrorEmpty, err)
+
+	sl.Insert(1, 1)
+	assert.Equal(t, 1, sl.First().Key())
+	assert.Equal(t, 1, sl.Last().Key())
+}
@@ -0,0 +1,154 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+// GetByRank returns the key-value pair with the passed rank in the skiplist, the rank of the minimum key is 0.
+// It returns ErrorOutOfRange if rank is not in range [0, Len())
+func (sl *Skiplist[K, V]) GetByRank(rank int) (K, V, error) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	if rank < 0 || rank >= sl.len {
+		return *new(K), *new(V), ErrorOutOfRange
+	}
+	e := sl.elementByRank(rank)
+	return e.key, e.value, nil
+}
+
+// GetByRevRank returns the key-value pair with the passed reverse rank in the skiplist, the reverse rank of the maximum key is 0.
+// It returns ErrorOutOfRange if rank is not in range [0, Len())
+func (sl *Skiplist[K, V]) GetByRevRank(rank int) (K, V, error) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	if rank < 0 || rank >= sl.len {
+		return *new(K), *new(V), ErrorOutOfRange
+	}
+	e := sl.elementByRank(sl.len - 1 - rank)
+	return e.key, e.value, nil
+}
+
+// RankOf returns the rank of the passed key in the skiplist, the rank of the minimum key is 0.
+// It returns -1 if the key is not in the skiplist
+func (sl *Skiplist[K, V]) RankOf(key K) int {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	return sl.rankOf(key)
+}
+
+// RevRankOf returns the reverse rank of the passed key in the skiplist, the reverse rank of the maximum key is 0.
+// It returns -1 if the key is not in the skiplist
+func (sl *Skiplist[K, V]) RevRankOf(key K) int {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	rank := sl.rankOf(key)
+	if rank < 0 {
+		return -1
+	}
+	return sl.len - 1 - rank
+}
+
+// RangeByRank traversals elements that their ranks are in range [start, stop] in ascending order of keys,
+// it will not stop until to the end of the range or the visitor returns false.
+// Like Redis's ZRANGE, negative start or stop means the offset from the end of the skiplist, -1 is the last element
+func (sl *Skiplist[K, V]) RangeByRank(start, stop int, visitor visitor.KvVisitor[K, V]) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	start, stop, ok := sl.normalizeRankRange(start, stop)
+	if !ok {
+		return
+	}
+	e := sl.elementByRank(start)
+	for i := start; i <= stop; i++ {
+		if !visitor(e.key, e.value) {
+			return
+		}
+		e = e.next[0]
+	}
+}
+
+// RevRangeByRank traversals elements that their reverse ranks are in range [start, stop] in descending order of keys,
+// it will not stop until to the end of the range or the visitor returns false.
+// Like Redis's ZREVRANGE, negative start or stop means the offset from the start of the skiplist, -1 is the first element
+func (sl *Skiplist[K, V]) RevRangeByRank(start, stop int, visitor visitor.KvVisitor[K, V]) {
+	sl.locker.RLock()
+	defer sl.locker.RUnlock()
+
+	start, stop, ok := sl.normalizeRankRange(start, stop)
+	if !ok {
+		return
+	}
+	// the list is singly linked, collect the elements first and then visit them backward
+	elements := make([]*Element[K, V], 0, stop-start+1)
+	e := sl.elementByRank(sl.len - 1 - stop)
+	for i := start; i <= stop; i++ {
+		elements = append(elements, e)
+		e = e.next[0]
+	}
+	for i := len(elements) - 1; i >= 0; i-- {
+		if !visitor(elements[i].key, elements[i].value) {
+			return
+		}
+	}
+}
+
+// normalizeRankRange converts negative ranks to offsets from the start and clamps the range into [0, len),
+// it returns false if the range is empty
+func (sl *Skiplist[K, V]) normalizeRankRange(start, stop int) (int, int, bool) {
+	if start < 0 {
+		start += sl.len
+	}
+	if stop < 0 {
+		stop += sl.len
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= sl.len {
+		stop = sl.len - 1
+	}
+	return start, stop, start <= stop
+}
+
+// elementByRank returns the element with the passed 0-based rank, the rank must be in range [0, len)
+func (sl *Skiplist[K, V]) elementByRank(rank int) *Element[K, V] {
+	// spans count elements from 1
+	rank++
+	traversed := 0
+	node := &sl.head
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		for node.next[i] != nil && traversed+node.span[i] <= rank {
+			traversed += node.span[i]
+			if traversed == rank {
+				return node.next[i]
+			}
+			node = &node.next[i].Node
+		}
+	}
+	return nil
+}
+
+// rankOf returns the 0-based rank of the passed key, or -1 if the key is not in the skiplist
+func (sl *Skiplist[K, V]) rankOf(key K) int {
+	traversed := 0
+	node := &sl.head
+	for i := sl.maxLevel - 1; i >= 0; i-- {
+		for next := node.next[i]; next != nil; next = next.next[i] {
+			cmp := sl.keyCmp(next.key, key)
+			if cmp > 0 {
+				break
+			}
+			traversed += node.span[i]
+			if cmp == 0 {
+				return traversed - 1
+			}
+			node = &next.Node
+		}
+	}
+	return -1
+}
@@ -0,0 +1,125 @@
+package skiplist
+
+import (
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/visitor"
+	"github.com/stretchr/testify/assert"
+	"math/rand"
+	"testing"
+)
+
+// checkSpans checks that each span equals the number of elements it skips
+func checkSpans[K, V any](t *testing.T, sl *Skiplist[K, V]) {
+	ranks := make(map[*Node[K, V]]int)
+	ranks[&sl.head] = 0
+	rank := 0
+	for e := sl.head.next[0]; e != nil; e = e.next[0] {
+		rank++
+		ranks[&e.Node] = rank
+	}
+	assert.Equal(t, sl.len, rank)
+	for node := range ranks {
+		for i, next := range node.next {
+			if next != nil {
+				assert.Equal(t, ranks[&next.Node]-ranks[node], node.span[i])
+			} else {
+				assert.Equal(t, sl.len-ranks[node], node.span[i])
+			}
+		}
+	}
+}
+
+func TestSkiplistRank(t *testing.T) {
+	sl := New[int, int](comparator.IntComparator, WithMaxLevel(6))
+	_, _, err := sl.GetByRank(0)
+	assert.Equal(t, ErrorOutOfRange, err)
+	assert.Equal(t, -1, sl.RankOf(0))
+
+	m := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		key := rand.Intn(2000)
+		sl.Insert(key, key*10)
+		m[key] = true
+		if i%3 == 0 {
+			key = rand.Intn(2000)
+			assert.Equal(t, m[key], sl.Remove(key))
+			delete(m, key)
+		}
+	}
+	_, _, _ = sl.PopFront()
+	_, _, _ = sl.PopBack()
+	checkSpans(t, sl)
+
+	keys := sl.Keys()
+	assert.Equal(t, len(keys), sl.Len())
+	for i, key := range keys {
+		assert.Equal(t, i, sl.RankOf(key))
+		assert.Equal(t, len(keys)-1-i, sl.RevRankOf(key))
+		k, v, err := sl.GetByRank(i)
+		assert.Nil(t, err)
+		assert.Equal(t, key, k)
+		assert.Equal(t, key*10, v)
+		k, _, _ = sl.GetByRevRank(len(keys) - 1 - i)
+		assert.Equal(t, key, k)
+	}
+	assert.Equal(t, -1, sl.RankOf(-1))
+	assert.Equal(t, -1, sl.RevRankOf(2001))
+	_, _, err = sl.GetByRank(len(keys))
+	assert.Equal(t, ErrorOutOfRange, err)
+	_, _, err = sl.GetByRevRank(-1)
+	assert.Equal(t, ErrorOutOfRange, err)
+}
+
+func TestSkiplistRangeByRank(t *testing.T) {
+	sl := New[int, int](comparator.IntComparator)
+	for i := 0; i < 10; i++ {
+		sl.Insert(i*2, i)
+	}
+
+	collect := func(rangeFn func(int, int, visitor.KvVisitor[int, int]), start, stop int) []int {
+		keys := make([]int, 0)
+		rangeFn(start, stop, func(key, value int) bool {
+			keys = append(keys, key)
+			return true
+		})
+		return keys
+	}
+	assert.Equal(t, []int{4, 6, 8}, collect(sl.RangeByRank, 2, 4))
+	assert.Equal(t, []int{14, 16, 18}, collect(sl.RangeByRank, -3, -1))
+	assert.Equal(t, []int{0, 2}, collect(sl.RangeByRank, -100, 1))
+	assert.Equal(t, []int{16, 18}, collect(sl.RangeByRank, 8, 100))
+	assert.Equal(t, []int{}, collect(sl.RangeByRank, 5, 4))
+	assert.Equal(t, []int{}, collect(sl.RangeByRank, 10, 12))
+
+	assert.Equal(t, []int{14, 12, 10}, collect(sl.RevRangeByRank, 2, 4))
+	assert.Equal(t, []int{4, 2, 0}, collect(sl.RevRangeByRank, -3, -1))
+	assert.Equal(t, []int{18}, collect(sl.RevRangeByRank, 0, 0))
+
+	n := 0
+	sl.RevRangeByRank(0, -1, func(key, value int) bool {
+		n++
+		return n < 4
+	})
+	assert.Equal(t, 4, n)
+}
+
+func TestSkiplistRankAfterBuild(t *testing.T) {
+	sl := New[int, int](comparator.IntComparator)
+	for i := 0; i < 500; i++ {
+		sl.Insert(i, i)
+	}
+	data, err := sl.MarshalBinary()
+	assert.Nil(t, err)
+
+	sl2 := New[int, int](comparator.IntComparator)
+	assert.Nil(t, sl2.UnmarshalBinary(data))
+	checkSpans(t, sl2)
+	assert.Equal(t, 250, sl2.RankOf(250))
+	k, _, _ := sl2.GetByRank(499)
+	assert.Equal(t, 499, k)
+
+	sl2.Insert(-1, -1)
+	sl2.Remove(100)
+	checkSpans(t, sl2)
+	assert.Equal(t, 250, sl2.RankOf(250))
+}
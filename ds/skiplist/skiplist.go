@@ -2,6 +2,7 @@ package skiplist
 
 import (
 	"errors"
+	"github.com/liyue201/gostl/utils/codec"
 	"github.com/liyue201/gostl/utils/comparator"
 	"github.com/liyue201/gostl/utils/sync"
 	"github.com/liyue201/gostl/utils/visitor"
@@ -14,12 +15,18 @@ var (
 	defaultMaxLevel = 10
 	defaultLocker   sync.FakeLocker
 )
-var ErrorNotFound = errors.New("not found")
+var (
+	ErrorNotFound   = errors.New("not found")
+	ErrorEmpty      = errors.New("skiplist is empty")
+	ErrorOutOfRange = errors.New("rank out of range")
+)
 
 // Options holds Skiplist's options
 type Options struct {
-	maxLevel int
-	locker   sync.Locker
+	maxLevel   int
+	locker     sync.Locker
+	keyCodec   any
+	valueCodec any
 }
 
 // Option is a function used to set Options
@@ -39,9 +46,26 @@ func WithMaxLevel(maxLevel int) Option {
 	}
 }
 
+// WithKeyCodec sets the codec used to encode keys in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of keys, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithKeyCodec[K any](c codec.Codec[K]) Option {
+	return func(option *Options) {
+		option.keyCodec = c
+	}
+}
+
+// WithValueCodec sets the codec used to encode values in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of values, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithValueCodec[V any](c codec.Codec[V]) Option {
+	return func(option *Options) {
+		option.valueCodec = c
+	}
+}
+
 // Node is a list node
 type Node[K, V any] struct {
 	next []*Element[K, V]
+	span []int // span[i] is the number of elements from this node to next[i], or to the end of the list if next[i] is nil
 }
 
 // Element is a kind of node with key-value data
@@ -59,7 +83,10 @@ type Skiplist[K, V any] struct {
 	keyCmp         comparator.Comparator[K]
 	len            int
 	prevNodesCache []*Node[K, V]
+	prevRanksCache []int
 	rander         *rand.Rand
+	keyCodec       codec.Codec[K]
+	valueCodec     codec.Codec[V]
 }
 
 // New news a Skiplist
@@ -72,13 +99,17 @@ func New[K, V any](cmp comparator.Comparator[K], opts ...Option) *Skiplist[K, V]
 		opt(&option)
 	}
 	l := &Skiplist[K, V]{
-		locker:   option.locker,
-		maxLevel: option.maxLevel,
-		keyCmp:   cmp,
-		rander:   rand.New(rand.NewSource(time.Now().Unix())),
+		locker:     option.locker,
+		maxLevel:   option.maxLevel,
+		keyCmp:     cmp,
+		rander:     rand.New(rand.NewSource(time.Now().Unix())),
+		keyCodec:   codec.Get[K](option.keyCodec),
+		valueCodec: codec.Get[V](option.valueCodec),
 	}
 	l.head.next = make([]*Element[K, V], l.maxLevel)
+	l.head.span = make([]int, l.maxLevel)
 	l.prevNodesCache = make([]*Node[K, V], l.maxLevel)
+	l.prevRanksCache = make([]int, l.maxLevel)
 	return l
 }
 
@@ -86,6 +117,11 @@ func New[K, V any](cmp comparator.Comparator[K], opts ...Option) *Skiplist[K, V]
 func (sl *Skiplist[K, V]) Insert(key K, value V) {
 	sl.locker.Lock()
 	defer sl.locker.Unlock()
+
+	sl.insert(key, value)
+}
+
+func (sl *Skiplist[K, V]) insert(key K, value V) {
 	prevs := sl.findPrevNodes(key)
 
 	if prevs[0].next[0] != nil && sl.keyCmp(prevs[0].next[0].key, key) == 0 {
@@ -101,12 +137,19 @@ func (sl *Skiplist[K, V]) Insert(key K, value V) {
 		value: value,
 		Node: Node[K, V]{
 			next: make([]*Element[K, V], level),
+			span: make([]int, level),
 		},
 	}
 
+	ranks := sl.prevRanksCache
 	for i := range e.next {
 		e.next[i] = prevs[i].next[i]
 		prevs[i].next[i] = e
+		e.span[i] = prevs[i].span[i] - (ranks[0] - ranks[i])
+		prevs[i].span[i] = ranks[0] - ranks[i] + 1
+	}
+	for i := level; i < sl.maxLevel; i++ {
+		prevs[i].span[i]++
 	}
 
 	sl.len++
@@ -139,6 +182,10 @@ func (sl *Skiplist[K, V]) Remove(key K) bool {
 	sl.locker.Lock()
 	defer sl.locker.Unlock()
 
+	return sl.remove(key)
+}
+
+func (sl *Skiplist[K, V]) remove(key K) bool {
 	prevs := sl.findPrevNodes(key)
 	element := prevs[0].next[0]
 	if element == nil {
@@ -148,8 +195,13 @@ func (sl *Skiplist[K, V]) Remove(key K) bool {
 		return false
 	}
 
-	for i, v := range element.next {
-		prevs[i].next[i] = v
+	for i := range prevs {
+		if i < len(element.next) {
+			prevs[i].next[i] = element.next[i]
+			prevs[i].span[i] += element.span[i] - 1
+		} else {
+			prevs[i].span[i]--
+		}
 	}
 	sl.len--
 	return true
@@ -162,6 +214,14 @@ func (sl *Skiplist[K, V]) Len() int {
 	return sl.len
 }
 
+func (sl *Skiplist[K, V]) clear() {
+	for i := range sl.head.next {
+		sl.head.next[i] = nil
+		sl.head.span[i] = 0
+	}
+	sl.len = 0
+}
+
 func (sl *Skiplist[K, V]) randomLevel() int {
 	total := uint64(1)<<uint64(sl.maxLevel) - 1 // 2^n-1
 	k := sl.rander.Uint64() % total
@@ -175,19 +235,25 @@ func (sl *Skiplist[K, V]) randomLevel() int {
 	return level
 }
 
+// findPrevNodes returns the last node before the passed key at each level,
+// and stores the ranks of these nodes in prevRanksCache
 func (sl *Skiplist[K, V]) findPrevNodes(key K) []*Node[K, V] {
 	prevs := sl.prevNodesCache
+	ranks := sl.prevRanksCache
 	prev := &sl.head
+	rank := 0
 	for i := sl.maxLevel - 1; i >= 0; i-- {
 		if sl.head.next[i] != nil {
 			for next := prev.next[i]; next != nil; next = next.next[i] {
 				if sl.keyCmp(next.key, key) >= 0 {
 					break
 				}
+				rank += prev.span[i]
 				prev = &next.Node
 			}
 		}
 		prevs[i] = prev
+		ranks[i] = rank
 	}
 	return prevs
 }
@@ -0,0 +1,47 @@
+package sortedset
+
+// Bound is a boundary of a range used in ZRangeByScore and ZRangeByLex
+type Bound[T any] struct {
+	value     T
+	exclusive bool
+	infinity  int // -1 for negative infinity, 1 for positive infinity, 0 for a finite bound
+}
+
+// Inclusive returns a bound which includes the value
+func Inclusive[T any](value T) Bound[T] {
+	return Bound[T]{value: value}
+}
+
+// Exclusive returns a bound which excludes the value
+func Exclusive[T any](value T) Bound[T] {
+	return Bound[T]{value: value, exclusive: true}
+}
+
+// NegInf returns a bound which is less than any value, like "-" in Redis's ZRANGEBYLEX
+func NegInf[T any]() Bound[T] {
+	return Bound[T]{infinity: -1}
+}
+
+// PosInf returns a bound which is greater than any value, like "+" in Redis's ZRANGEBYLEX
+func PosInf[T any]() Bound[T] {
+	return Bound[T]{infinity: 1}
+}
+
+// Value returns the value of the bound
+func (b Bound[T]) Value() T {
+	return b.value
+}
+
+// IsExclusive returns true if the bound excludes its value
+func (b Bound[T]) IsExclusive() bool {
+	return b.exclusive
+}
+
+// belowMax returns true if v doesn't exceed the bound b as the maximum of a range
+func belowMax[T any](v T, b Bound[T], cmp func(a, b T) int) bool {
+	if b.infinity != 0 {
+		return b.infinity > 0
+	}
+	c := cmp(v, b.value)
+	return c < 0 || (c == 0 && !b.exclusive)
+}
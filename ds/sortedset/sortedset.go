@@ -0,0 +1,290 @@
+package sortedset
+
+import (
+	"errors"
+	"math"
+	gosync "sync"
+
+	"github.com/liyue201/gostl/ds/skiplist"
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/liyue201/gostl/utils/sync"
+	"github.com/liyue201/gostl/utils/visitor"
+)
+
+var (
+	defaultLocker sync.FakeLocker
+)
+
+var (
+	ErrorNotFound = errors.New("not found")
+	ErrorEmpty    = errors.New("sorted set is empty")
+	ErrorNaN      = errors.New("score is NaN")
+)
+
+// Options holds SortedSet's options
+type Options struct {
+	locker sync.Locker
+}
+
+// Option is a function type used to set Options
+type Option func(option *Options)
+
+// WithGoroutineSafe is used to set a sorted set goroutine-safe
+func WithGoroutineSafe() Option {
+	return func(option *Options) {
+		option.locker = &gosync.RWMutex{}
+	}
+}
+
+// entry is the key of the skiplist, entries are sorted by score and then by member.
+// edge is used to make a key which is less (-1) or greater (1) than all the entries with the same score
+type entry[M any] struct {
+	score  float64
+	member M
+	edge   int8
+}
+
+// SortedSet is a set of unique members ordered by their scores like Redis's ZSET,
+// members with the same score are ordered by the member comparator.
+// It uses a skiplist to keep the order and a hash map to look up scores by members
+type SortedSet[M comparable] struct {
+	list      *skiplist.Skiplist[entry[M], struct{}]
+	scores    map[M]float64
+	memberCmp comparator.Comparator[M]
+	locker    sync.Locker
+}
+
+// New creates a new SortedSet, the cmp is used to order members with the same score
+func New[M comparable](cmp comparator.Comparator[M], opts ...Option) *SortedSet[M] {
+	option := Options{
+		locker: defaultLocker,
+	}
+	for _, opt := range opts {
+		opt(&option)
+	}
+	entryCmp := func(a, b entry[M]) int {
+		if a.score < b.score {
+			return -1
+		}
+		if a.score > b.score {
+			return 1
+		}
+		if a.edge != b.edge {
+			return int(a.edge) - int(b.edge)
+		}
+		if a.edge != 0 {
+			return 0
+		}
+		return cmp(a.member, b.member)
+	}
+	return &SortedSet[M]{
+		list:      skiplist.New[entry[M], struct{}](entryCmp, skiplist.WithMaxLevel(32)),
+		scores:    make(map[M]float64),
+		memberCmp: cmp,
+		locker:    option.locker,
+	}
+}
+
+// ZAdd adds the member with the score to the sorted set, the score is updated if the member is already in the sorted set.
+// It returns true if the member is newly added, and returns ErrorNaN if the score is NaN
+func (s *SortedSet[M]) ZAdd(member M, score float64) (bool, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	if math.IsNaN(score) {
+		return false, ErrorNaN
+	}
+	_, ok := s.scores[member]
+	s.set(member, score)
+	return !ok, nil
+}
+
+// ZIncrBy increments the score of the member by delta and returns the new score,
+// the member is added with score delta if it is not in the sorted set.
+// It returns ErrorNaN if the new score is NaN
+func (s *SortedSet[M]) ZIncrBy(member M, delta float64) (float64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	score := s.scores[member] + delta
+	if math.IsNaN(score) {
+		return 0, ErrorNaN
+	}
+	s.set(member, score)
+	return score, nil
+}
+
+// ZScore returns the score of the member, or ErrorNotFound if the member is not in the sorted set
+func (s *SortedSet[M]) ZScore(member M) (float64, error) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	score, ok := s.scores[member]
+	if !ok {
+		return 0, ErrorNotFound
+	}
+	return score, nil
+}
+
+// ZRem removes the member from the sorted set, and returns true if the member is in the sorted set
+func (s *SortedSet[M]) ZRem(member M) bool {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	score, ok := s.scores[member]
+	if !ok {
+		return false
+	}
+	delete(s.scores, member)
+	s.list.Remove(entry[M]{score: score, member: member})
+	return true
+}
+
+// ZCard returns the number of members in the sorted set
+func (s *SortedSet[M]) ZCard() int {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	return len(s.scores)
+}
+
+// ZRank returns the rank of the member ordered by score from low to high, the rank of the lowest member is 0.
+// It returns -1 if the member is not in the sorted set
+func (s *SortedSet[M]) ZRank(member M) int {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	score, ok := s.scores[member]
+	if !ok {
+		return -1
+	}
+	return s.list.RankOf(entry[M]{score: score, member: member})
+}
+
+// ZRevRank returns the rank of the member ordered by score from high to low, the rank of the highest member is 0.
+// It returns -1 if the member is not in the sorted set
+func (s *SortedSet[M]) ZRevRank(member M) int {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	score, ok := s.scores[member]
+	if !ok {
+		return -1
+	}
+	return s.list.RevRankOf(entry[M]{score: score, member: member})
+}
+
+// ZRange traversals members with ranks in range [start, stop] from low score to high score,
+// negative start or stop means the offset from the end, -1 is the member with the highest score
+func (s *SortedSet[M]) ZRange(start, stop int, visitor visitor.KvVisitor[M, float64]) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	s.list.RangeByRank(start, stop, func(e entry[M], _ struct{}) bool {
+		return visitor(e.member, e.score)
+	})
+}
+
+// ZRevRange traversals members with reverse ranks in range [start, stop] from high score to low score,
+// negative start or stop means the offset from the end, -1 is the member with the lowest score
+func (s *SortedSet[M]) ZRevRange(start, stop int, visitor visitor.KvVisitor[M, float64]) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	s.list.RevRangeByRank(start, stop, func(e entry[M], _ struct{}) bool {
+		return visitor(e.member, e.score)
+	})
+}
+
+// ZRangeByScore traversals members with scores between min and max from low score to high score,
+// it will not stop until to the end of the range or the visitor returns false
+func (s *SortedSet[M]) ZRangeByScore(min, max Bound[float64], visitor visitor.KvVisitor[M, float64]) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	var iter *skiplist.SkiplistIterator[entry[M], struct{}]
+	switch {
+	case min.infinity < 0:
+		iter = s.list.First()
+	case min.infinity > 0:
+		return
+	case min.exclusive:
+		iter = s.list.LowerBound(entry[M]{score: min.value, edge: 1})
+	default:
+		iter = s.list.LowerBound(entry[M]{score: min.value, edge: -1})
+	}
+	for ; iter.IsValid(); iter.Next() {
+		e := iter.Key()
+		if !belowMax(e.score, max, comparator.OrderedTypeCmp[float64]) || !visitor(e.member, e.score) {
+			return
+		}
+	}
+}
+
+// ZRangeByLex traversals members between min and max in the order of the member comparator.
+// Like Redis's ZRANGEBYLEX, all the members are expected to have the same score, otherwise the result is unspecified
+func (s *SortedSet[M]) ZRangeByLex(min, max Bound[M], visitor visitor.KvVisitor[M, float64]) {
+	s.locker.RLock()
+	defer s.locker.RUnlock()
+
+	iter := s.list.First()
+	if !iter.IsValid() {
+		return
+	}
+	score := iter.Key().score
+	switch {
+	case min.infinity < 0:
+	case min.infinity > 0:
+		return
+	case min.exclusive:
+		iter = s.list.UpperBound(entry[M]{score: score, member: min.value})
+	default:
+		iter = s.list.LowerBound(entry[M]{score: score, member: min.value})
+	}
+	for ; iter.IsValid(); iter.Next() {
+		e := iter.Key()
+		if !belowMax(e.member, max, s.memberCmp) || !visitor(e.member, e.score) {
+			return
+		}
+	}
+}
+
+// ZPopMin removes the member with the lowest score and returns it with its score,
+// returns ErrorEmpty if the sorted set is empty
+func (s *SortedSet[M]) ZPopMin() (M, float64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	e, _, err := s.list.PopFront()
+	if err != nil {
+		return *new(M), 0, ErrorEmpty
+	}
+	delete(s.scores, e.member)
+	return e.member, e.score, nil
+}
+
+// ZPopMax removes the member with the highest score and returns it with its score,
+// returns ErrorEmpty if the sorted set is empty
+func (s *SortedSet[M]) ZPopMax() (M, float64, error) {
+	s.locker.Lock()
+	defer s.locker.Unlock()
+
+	e, _, err := s.list.PopBack()
+	if err != nil {
+		return *new(M), 0, ErrorEmpty
+	}
+	delete(s.scores, e.member)
+	return e.member, e.score, nil
+}
+
+// set sets the score of the member, the score must not be NaN
+func (s *SortedSet[M]) set(member M, score float64) {
+	if old, ok := s.scores[member]; ok {
+		if old == score {
+			return
+		}
+		s.list.Remove(entry[M]{score: old, member: member})
+	}
+	s.scores[member] = score
+	s.list.Insert(entry[M]{score: score, member: member}, struct{}{})
+}
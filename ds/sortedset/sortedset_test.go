@@ -0,0 +1,183 @@
+package sortedset
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/comparator"
+	"github.com/stretchr/testify/assert"
+)
+
+type pair struct {
+	member string
+	score  float64
+}
+
+func collect(rangeFn func(visitor func(string, float64) bool)) []pair {
+	pairs := make([]pair, 0)
+	rangeFn(func(member string, score float64) bool {
+		pairs = append(pairs, pair{member, score})
+		return true
+	})
+	return pairs
+}
+
+func TestSortedSet(t *testing.T) {
+	s := New[string](comparator.StringComparator, WithGoroutineSafe())
+
+	added, err := s.ZAdd("a", 3)
+	assert.True(t, added)
+	assert.Nil(t, err)
+	s.ZAdd("b", 1)
+	s.ZAdd("c", 2)
+	s.ZAdd("d", 2)
+	added, _ = s.ZAdd("a", 0)
+	assert.False(t, added)
+	_, err = s.ZAdd("e", math.NaN())
+	assert.Equal(t, ErrorNaN, err)
+	assert.Equal(t, 4, s.ZCard())
+
+	score, err := s.ZScore("a")
+	assert.Nil(t, err)
+	assert.Equal(t, 0.0, score)
+	_, err = s.ZScore("e")
+	assert.Equal(t, ErrorNotFound, err)
+
+	// a:0 b:1 c:2 d:2
+	assert.Equal(t, 0, s.ZRank("a"))
+	assert.Equal(t, 3, s.ZRank("d"))
+	assert.Equal(t, 1, s.ZRevRank("c"))
+	assert.Equal(t, -1, s.ZRank("e"))
+	assert.Equal(t, -1, s.ZRevRank("e"))
+
+	score, err = s.ZIncrBy("a", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 5.0, score)
+	score, _ = s.ZIncrBy("e", 1.5)
+	assert.Equal(t, 1.5, score)
+	_, err = s.ZIncrBy("a", math.Inf(1))
+	assert.Nil(t, err)
+	_, err = s.ZIncrBy("a", math.Inf(-1))
+	assert.Equal(t, ErrorNaN, err)
+
+	// b:1 e:1.5 c:2 d:2 a:+inf
+	assert.Equal(t, []pair{{"b", 1}, {"e", 1.5}, {"c", 2}}, collect(func(v func(string, float64) bool) { s.ZRange(0, 2, v) }))
+	assert.Equal(t, []pair{{"a", math.Inf(1)}, {"d", 2}}, collect(func(v func(string, float64) bool) { s.ZRevRange(0, 1, v) }))
+
+	assert.True(t, s.ZRem("e"))
+	assert.False(t, s.ZRem("e"))
+	assert.Equal(t, 4, s.ZCard())
+
+	member, score, err := s.ZPopMin()
+	assert.Nil(t, err)
+	assert.Equal(t, "b", member)
+	assert.Equal(t, 1.0, score)
+	member, score, _ = s.ZPopMax()
+	assert.Equal(t, "a", member)
+	assert.Equal(t, math.Inf(1), score)
+	s.ZPopMin()
+	s.ZPopMin()
+	_, _, err = s.ZPopMin()
+	assert.Equal(t, ErrorEmpty, err)
+	_, _, err = s.ZPopMax()
+	assert.Equal(t, ErrorEmpty, err)
+	assert.Equal(t, 0, s.ZCard())
+}
+
+func TestZRangeByScore(t *testing.T) {
+	s := New[string](comparator.StringComparator)
+	for i := 0; i < 10; i++ {
+		s.ZAdd(fmt.Sprintf("m%d", i), float64(i/2))
+	}
+	rangeByScore := func(min, max Bound[float64]) []string {
+		members := make([]string, 0)
+		s.ZRangeByScore(min, max, func(member string, score float64) bool {
+			members = append(members, member)
+			return true
+		})
+		return members
+	}
+	assert.Equal(t, []string{"m2", "m3", "m4", "m5"}, rangeByScore(Inclusive(1.0), Inclusive(2.0)))
+	assert.Equal(t, []string{"m4", "m5"}, rangeByScore(Exclusive(1.0), Inclusive(2.0)))
+	assert.Equal(t, []string{"m2", "m3"}, rangeByScore(Inclusive(1.0), Exclusive(2.0)))
+	assert.Equal(t, []string{}, rangeByScore(Exclusive(1.0), Exclusive(2.0)))
+	assert.Equal(t, []string{"m0", "m1"}, rangeByScore(NegInf[float64](), Inclusive(0.5)))
+	assert.Equal(t, []string{"m8", "m9"}, rangeByScore(Inclusive(3.5), PosInf[float64]()))
+	assert.Equal(t, 10, len(rangeByScore(Inclusive(math.Inf(-1)), Inclusive(math.Inf(1)))))
+	assert.Equal(t, []string{}, rangeByScore(PosInf[float64](), PosInf[float64]()))
+
+	n := 0
+	s.ZRangeByScore(NegInf[float64](), PosInf[float64](), func(member string, score float64) bool {
+		n++
+		return n < 3
+	})
+	assert.Equal(t, 3, n)
+}
+
+func TestZRangeByLex(t *testing.T) {
+	s := New[string](comparator.StringComparator)
+	for _, m := range []string{"a", "b", "c", "d", "e", "f", "g"} {
+		s.ZAdd(m, 0)
+	}
+	rangeByLex := func(min, max Bound[string]) []string {
+		members := make([]string, 0)
+		s.ZRangeByLex(min, max, func(member string, score float64) bool {
+			members = append(members, member)
+			return true
+		})
+		return members
+	}
+	assert.Equal(t, []string{"a", "b", "c"}, rangeByLex(NegInf[string](), Inclusive("c")))
+	assert.Equal(t, []string{"a", "b"}, rangeByLex(NegInf[string](), Exclusive("c")))
+	assert.Equal(t, []string{"b", "c", "d", "e", "f"}, rangeByLex(Inclusive("aaa"), Exclusive("g")))
+	assert.Equal(t, []string{"c", "d", "e", "f", "g"}, rangeByLex(Exclusive("b"), PosInf[string]()))
+	assert.Equal(t, []string{}, rangeByLex(PosInf[string](), PosInf[string]()))
+
+	empty := New[string](comparator.StringComparator)
+	empty.ZRangeByLex(NegInf[string](), PosInf[string](), func(string, float64) bool {
+		t.Fatal("unexpected member")
+		return false
+	})
+}
+
+func TestSortedSetRandom(t *testing.T) {
+	s := New[int](comparator.IntComparator)
+	scores := make(map[int]float64)
+	for i := 0; i < 2000; i++ {
+		member := rand.Intn(300)
+		switch rand.Intn(3) {
+		case 0:
+			score := float64(rand.Intn(50))
+			s.ZAdd(member, score)
+			scores[member] = score
+		case 1:
+			score, _ := s.ZIncrBy(member, float64(rand.Intn(10)-5))
+			scores[member] = score
+		default:
+			_, ok := scores[member]
+			assert.Equal(t, ok, s.ZRem(member))
+			delete(scores, member)
+		}
+	}
+
+	members := make([]int, 0, len(scores))
+	for m := range scores {
+		members = append(members, m)
+	}
+	sort.Slice(members, func(i, j int) bool {
+		if scores[members[i]] != scores[members[j]] {
+			return scores[members[i]] < scores[members[j]]
+		}
+		return members[i] < members[j]
+	})
+	assert.Equal(t, len(members), s.ZCard())
+	for i, m := range members {
+		assert.Equal(t, i, s.ZRank(m))
+		assert.Equal(t, len(members)-1-i, s.ZRevRank(m))
+		score, _ := s.ZScore(m)
+		assert.Equal(t, scores[m], score)
+	}
+}
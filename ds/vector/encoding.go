@@ -0,0 +1,73 @@
+package vector
+
+import (
+	"encoding/json"
+
+	"github.com/liyue201/gostl/utils/codec"
+)
+
+// MarshalBinary encodes the vector with its codec, it implements encoding.BinaryMarshaler
+func (v *Vector[T]) MarshalBinary() ([]byte, error) {
+	if err := codec.Check(v.codec); err != nil {
+		return nil, err
+	}
+	var err error
+	buf := codec.AppendUvarint(nil, uint64(len(v.data)))
+	for i := range v.data {
+		if buf, err = v.codec.Encode(buf, v.data[i]); err != nil {
+			return nil, err
+		}
+	}
+	return buf, nil
+}
+
+// UnmarshalBinary replaces the content of the vector with the data returned by MarshalBinary,
+// it implements encoding.BinaryUnmarshaler. Note that the vector must be created by New
+func (v *Vector[T]) UnmarshalBinary(data []byte) error {
+	if err := codec.Check(v.codec); err != nil {
+		return err
+	}
+	n, data, err := codec.ReadLen(data)
+	if err != nil {
+		return err
+	}
+	values := make([]T, n)
+	for i := 0; i < n; i++ {
+		if values[i], data, err = v.codec.Decode(data); err != nil {
+			return err
+		}
+	}
+	if len(data) != 0 {
+		return codec.ErrorCorruptData
+	}
+	v.data = values
+	return nil
+}
+
+// MarshalJSON encodes the vector into a JSON array, it implements json.Marshaler
+func (v *Vector[T]) MarshalJSON() ([]byte, error) {
+	if v.data == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(v.data)
+}
+
+// UnmarshalJSON replaces the content of the vector with the data returned by MarshalJSON, it implements json.Unmarshaler
+func (v *Vector[T]) UnmarshalJSON(data []byte) error {
+	values := make([]T, 0)
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	v.data = values
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, it is the same as MarshalBinary
+func (v *Vector[T]) GobEncode() ([]byte, error) {
+	return v.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder, it is the same as UnmarshalBinary
+func (v *Vector[T]) GobDecode(data []byte) error {
+	return v.UnmarshalBinary(data)
+}
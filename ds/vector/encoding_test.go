@@ -0,0 +1,61 @@
+package vector
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"testing"
+
+	"github.com/liyue201/gostl/utils/codec"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorBinary(t *testing.T) {
+	v := New[string](WithCodec[string](codec.String[string]{}))
+	v.PushBack("a")
+	v.PushBack("")
+	v.PushBack("ccc")
+	data, err := v.MarshalBinary()
+	assert.Nil(t, err)
+
+	v2 := New[string](WithCodec[string](codec.String[string]{}))
+	v2.PushBack("x")
+	assert.Nil(t, v2.UnmarshalBinary(data))
+	assert.Equal(t, []string{"a", "", "ccc"}, v2.Data())
+
+	assert.NotNil(t, v2.UnmarshalBinary(data[:len(data)-1]))
+}
+
+func TestVectorJSONAndGob(t *testing.T) {
+	v := New[int]()
+	data, err := json.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, "[]", string(data))
+
+	v.PushBack(1)
+	v.PushBack(2)
+	data, err = json.Marshal(v)
+	assert.Nil(t, err)
+	assert.Equal(t, "[1,2]", string(data))
+
+	v2 := New[int]()
+	assert.Nil(t, json.Unmarshal(data, v2))
+	assert.Equal(t, []int{1, 2}, v2.Data())
+
+	var buf bytes.Buffer
+	assert.Nil(t, gob.NewEncoder(&buf).Encode(v))
+	v3 := New[int]()
+	assert.Nil(t, gob.NewDecoder(&buf).Decode(v3))
+	assert.Equal(t, []int{1, 2}, v3.Data())
+}
+
+func TestVectorWrongCodec(t *testing.T) {
+	v := New[int](WithCodec[string](codec.String[string]{}))
+	_, err := v.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	v.PushBack(1)
+	_, err = v.MarshalBinary()
+	assert.ErrorIs(t, err, codec.ErrorCodecType)
+	assert.ErrorIs(t, v.UnmarshalBinary([]byte{0}), codec.ErrorCodecType)
+	assert.Equal(t, []int{1}, v.Data())
+}
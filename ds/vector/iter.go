@@ -0,0 +1,38 @@
+//go:build go1.23
+
+package vector
+
+import "iter"
+
+// All returns an iterator over index-value pairs in the vector, from front to back
+func (v *Vector[T]) All() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := 0; i < len(v.data); i++ {
+			if !yield(i, v.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over index-value pairs in the vector, from back to front
+func (v *Vector[T]) Backward() iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		for i := len(v.data) - 1; i >= 0; i-- {
+			if !yield(i, v.data[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns an iterator over values in the vector, from front to back
+func (v *Vector[T]) Values() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < len(v.data); i++ {
+			if !yield(v.data[i]) {
+				return
+			}
+		}
+	}
+}
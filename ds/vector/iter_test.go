@@ -0,0 +1,52 @@
+//go:build go1.23
+
+package vector
+
+import (
+	"testing"
+
+	"github.com/liyue201/gostl/utils/iterator"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVectorIterSeq(t *testing.T) {
+	v := New[int]()
+	for i := 0; i < 5; i++ {
+		v.PushBack(i * 10)
+	}
+
+	n := 0
+	for i, val := range v.All() {
+		assert.Equal(t, n, i)
+		assert.Equal(t, i*10, val)
+		n++
+	}
+	assert.Equal(t, 5, n)
+
+	for i, val := range v.Backward() {
+		n--
+		assert.Equal(t, n, i)
+		assert.Equal(t, i*10, val)
+	}
+
+	values := make([]int, 0)
+	for val := range v.Values() {
+		if val > 20 {
+			break
+		}
+		values = append(values, val)
+	}
+	assert.Equal(t, []int{0, 10, 20}, values)
+
+	values = values[:0]
+	for val := range iterator.Seq[int](v.IterAt(1), v.IterAt(4)) {
+		values = append(values, val)
+	}
+	assert.Equal(t, []int{10, 20, 30}, values)
+
+	values = values[:0]
+	for val := range iterator.Seq[int](v.Begin(), nil) {
+		values = append(values, val)
+	}
+	assert.Equal(t, []int{0, 10, 20, 30, 40}, values)
+}
@@ -2,12 +2,14 @@ package vector
 
 import (
 	"fmt"
+	"github.com/liyue201/gostl/utils/codec"
 	"github.com/liyue201/gostl/utils/iterator"
 )
 
 // Options holds the Vector's options
 type Options struct {
 	capacity int
+	codec    any
 }
 
 // Option is a function type used to set Options
@@ -20,9 +22,18 @@ func WithCapacity(capacity int) Option {
 	}
 }
 
+// WithCodec sets the codec used to encode values in MarshalBinary and GobEncode, the default codec is codec.Gob.
+// If c doesn't match the type of values, MarshalBinary and UnmarshalBinary return an error wrapping codec.ErrorCodecType
+func WithCodec[T any](c codec.Codec[T]) Option {
+	return func(option *Options) {
+		option.codec = c
+	}
+}
+
 // Vector is a linear data structure, the internal is a slice
 type Vector[T any] struct {
-	data []T
+	data  []T
+	codec codec.Codec[T]
 }
 
 // New creates a new Vector
@@ -32,13 +43,14 @@ func New[T any](opts ...Option) *Vector[T] {
 		opt(&option)
 	}
 	return &Vector[T]{
-		data: make([]T, 0, option.capacity),
+		data:  make([]T, 0, option.capacity),
+		codec: codec.Get[T](option.codec),
 	}
 }
 
 // NewFromVector news a Vector from other Vector
 func NewFromVector[T any](other *Vector[T]) *Vector[T] {
-	v := &Vector[T]{data: make([]T, other.Size(), other.Capacity())}
+	v := &Vector[T]{data: make([]T, other.Size(), other.Capacity()), codec: other.codec}
 	for i := range other.data {
 		v.data[i] = other.data[i]
 	}
@@ -0,0 +1,221 @@
+package codec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+
+	"github.com/liyue201/gostl/utils/comparator"
+)
+
+var ErrorCorruptData = errors.New("corrupt data")
+
+// ErrorCodecType is returned when a container is created with a codec which doesn't match its element type
+var ErrorCodecType = errors.New("codec doesn't match the element type")
+
+// Codec is an interface used to encode and decode elements of containers
+type Codec[T any] interface {
+	// Encode appends the encoding of value to buf and returns the extended buffer
+	Encode(buf []byte, value T) ([]byte, error)
+
+	// Decode decodes a value from the front of data, and returns the value and the remaining data
+	Decode(data []byte) (T, []byte, error)
+}
+
+// Get returns c as a Codec[T], or a Gob codec if c is nil. If c is not a Codec[T], it returns a codec whose
+// Encode and Decode always fail with an error wrapping ErrorCodecType, and Check returns the error
+func Get[T any](c any) Codec[T] {
+	if c == nil {
+		return Gob[T]{}
+	}
+	ret, ok := c.(Codec[T])
+	if !ok {
+		return mismatch[T]{err: fmt.Errorf("%w: %T is not a codec of %v", ErrorCodecType, c, reflect.TypeOf((*T)(nil)).Elem())}
+	}
+	return ret
+}
+
+// Check returns the error of the first codec which is returned by Get with a codec of a wrong type, otherwise returns nil
+func Check(codecs ...any) error {
+	for _, c := range codecs {
+		if m, ok := c.(interface{ mismatchError() error }); ok {
+			return m.mismatchError()
+		}
+	}
+	return nil
+}
+
+// mismatch is returned by Get in place of a codec of a wrong type
+type mismatch[T any] struct {
+	err error
+}
+
+func (m mismatch[T]) mismatchError() error {
+	return m.err
+}
+
+// Encode always returns the mismatch error
+func (m mismatch[T]) Encode(buf []byte, value T) ([]byte, error) {
+	return buf, m.err
+}
+
+// Decode always returns the mismatch error
+func (m mismatch[T]) Decode(data []byte) (T, []byte, error) {
+	return *new(T), data, m.err
+}
+
+// Gob is a Codec encoding values with encoding/gob, it works for any type supported by gob
+type Gob[T any] struct{}
+
+// Encode appends the encoding of value to buf and returns the extended buffer
+func (Gob[T]) Encode(buf []byte, value T) ([]byte, error) {
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(&value); err != nil {
+		return buf, err
+	}
+	return appendChunk(buf, b.Bytes()), nil
+}
+
+// Decode decodes a value from the front of data, and returns the value and the remaining data
+func (Gob[T]) Decode(data []byte) (T, []byte, error) {
+	var value T
+	chunk, data, err := readChunk(data)
+	if err != nil {
+		return value, data, err
+	}
+	err = gob.NewDecoder(bytes.NewReader(chunk)).Decode(&value)
+	return value, data, err
+}
+
+// JSON is a Codec encoding values with encoding/json
+type JSON[T any] struct{}
+
+// Encode appends the encoding of value to buf and returns the extended buffer
+func (JSON[T]) Encode(buf []byte, value T) ([]byte, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return buf, err
+	}
+	return appendChunk(buf, b), nil
+}
+
+// Decode decodes a value from the front of data, and returns the value and the remaining data
+func (JSON[T]) Decode(data []byte) (T, []byte, error) {
+	var value T
+	chunk, data, err := readChunk(data)
+	if err != nil {
+		return value, data, err
+	}
+	err = json.Unmarshal(chunk, &value)
+	return value, data, err
+}
+
+// Integer is a Codec encoding integers as varints
+type Integer[T comparator.Integer] struct{}
+
+// Encode appends the encoding of value to buf and returns the extended buffer
+func (Integer[T]) Encode(buf []byte, value T) ([]byte, error) {
+	return AppendVarint(buf, int64(value)), nil
+}
+
+// Decode decodes a value from the front of data, and returns the value and the remaining data
+func (Integer[T]) Decode(data []byte) (T, []byte, error) {
+	v, data, err := ReadVarint(data)
+	return T(v), data, err
+}
+
+// Float is a Codec encoding floats as 8 bytes in little endian
+type Float[T comparator.Float] struct{}
+
+// Encode appends the encoding of value to buf and returns the extended buffer
+func (Float[T]) Encode(buf []byte, value T) ([]byte, error) {
+	var b [8]byte
+	binary.LittleEndian.PutUint64(b[:], math.Float64bits(float64(value)))
+	return append(buf, b[:]...), nil
+}
+
+// Decode decodes a value from the front of data, and returns the value and the remaining data
+func (Float[T]) Decode(data []byte) (T, []byte, error) {
+	if len(data) < 8 {
+		return 0, data, ErrorCorruptData
+	}
+	return T(math.Float64frombits(binary.LittleEndian.Uint64(data))), data[8:], nil
+}
+
+// String is a Codec encoding strings as a length followed by the bytes
+type String[T ~string] struct{}
+
+// Encode appends the encoding of value to buf and returns the extended buffer
+func (String[T]) Encode(buf []byte, value T) ([]byte, error) {
+	buf = AppendUvarint(buf, uint64(len(value)))
+	return append(buf, value...), nil
+}
+
+// Decode decodes a value from the front of data, and returns the value and the remaining data
+func (String[T]) Decode(data []byte) (T, []byte, error) {
+	chunk, data, err := readChunk(data)
+	return T(chunk), data, err
+}
+
+// AppendUvarint appends the varint encoding of x to buf
+func AppendUvarint(buf []byte, x uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(b[:], x)
+	return append(buf, b[:n]...)
+}
+
+// ReadUvarint reads a varint encoded unsigned integer from the front of data
+func ReadUvarint(data []byte) (uint64, []byte, error) {
+	x, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, data, ErrorCorruptData
+	}
+	return x, data[n:], nil
+}
+
+// AppendVarint appends the varint encoding of x to buf
+func AppendVarint(buf []byte, x int64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	n := binary.PutVarint(b[:], x)
+	return append(buf, b[:n]...)
+}
+
+// ReadVarint reads a varint encoded signed integer from the front of data
+func ReadVarint(data []byte) (int64, []byte, error) {
+	x, n := binary.Varint(data)
+	if n <= 0 {
+		return 0, data, ErrorCorruptData
+	}
+	return x, data[n:], nil
+}
+
+// ReadLen reads a varint encoded length from the front of data, the length must be not greater than len(data)
+// because each element takes one byte at least
+func ReadLen(data []byte) (int, []byte, error) {
+	n, data, err := ReadUvarint(data)
+	if err != nil {
+		return 0, data, err
+	}
+	if n > uint64(len(data)) {
+		return 0, data, ErrorCorruptData
+	}
+	return int(n), data, nil
+}
+
+func appendChunk(buf, chunk []byte) []byte {
+	buf = AppendUvarint(buf, uint64(len(chunk)))
+	return append(buf, chunk...)
+}
+
+func readChunk(data []byte) ([]byte, []byte, error) {
+	n, data, err := ReadLen(data)
+	if err != nil {
+		return nil, data, err
+	}
+	return data[:n], data[n:], nil
+}
@@ -0,0 +1,35 @@
+//go:build go1.23
+
+package iterator
+
+import "iter"
+
+// Seq returns an iterator over values in range [first, last).
+// If last is nil, it visits values until first becomes invalid
+func Seq[T any](first, last ConstIterator[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for it := first.Clone(); it.IsValid(); it.Next() {
+			if last != nil && it.Equal(last) {
+				return
+			}
+			if !yield(it.Value()) {
+				return
+			}
+		}
+	}
+}
+
+// KvSeq returns an iterator over key-value pairs in range [first, last).
+// If last is nil, it visits key-value pairs until first becomes invalid
+func KvSeq[K, V any](first, last ConstKvIterator[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for it := first.Clone().(ConstKvIterator[K, V]); it.IsValid(); it.Next() {
+			if last != nil && it.Equal(last) {
+				return
+			}
+			if !yield(it.Key(), it.Value()) {
+				return
+			}
+		}
+	}
+}